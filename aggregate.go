@@ -0,0 +1,207 @@
+package mysqlx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GroupBy is for MySQL GROUP BY statement. Each element should be a field name.
+type GroupBy []string
+
+func (g GroupBy) fields() []string {
+	ret := make([]string, 0, len(g))
+	for _, f := range g {
+		if f == "" {
+			continue
+		}
+		ret = append(ret, f)
+	}
+	return ret
+}
+
+// Having packages conditions in MySQL HAVING statement with AND logic. Only Cond, *Cond, Or, And types are
+// acceptable in the slice. Besides field names, Param in conditions could also be an alias of an Aggregation.
+type Having []interface{}
+
+func (h Having) pack(fieldMap map[string]*Field, b *binder) string {
+	return And(h).pack(fieldMap, b)
+}
+
+// Aggregation identifies an aggregate function in SELECT statement, such as "SUM(`amount`) AS `total`".
+type Aggregation struct {
+	// Func is the name of the aggregate function, such as COUNT, SUM, MIN, MAX and AVG.
+	Func string
+	// Param is the field name to aggregate. "*" is only allowed in COUNT.
+	Param string
+	// As is the alias of the aggregated result. It should be the same as the db tag in result structure.
+	// If not given, it will be lower-cased Func and Param joined by '_', such as "sum_amount". For COUNT(*),
+	// the default alias is "count".
+	As string
+}
+
+// Count returns an Aggregation with COUNT function. Param could be "*".
+func Count(param, as string) *Aggregation {
+	return &Aggregation{Func: "COUNT", Param: param, As: as}
+}
+
+// Sum returns an Aggregation with SUM function.
+func Sum(param, as string) *Aggregation {
+	return &Aggregation{Func: "SUM", Param: param, As: as}
+}
+
+// Min returns an Aggregation with MIN function.
+func Min(param, as string) *Aggregation {
+	return &Aggregation{Func: "MIN", Param: param, As: as}
+}
+
+// Max returns an Aggregation with MAX function.
+func Max(param, as string) *Aggregation {
+	return &Aggregation{Func: "MAX", Param: param, As: as}
+}
+
+// Avg returns an Aggregation with AVG function.
+func Avg(param, as string) *Aggregation {
+	return &Aggregation{Func: "AVG", Param: param, As: as}
+}
+
+func (a *Aggregation) pack(fieldMap map[string]*Field) (string, error) {
+	fn := strings.ToUpper(a.Func)
+	switch fn {
+	case "COUNT", "SUM", "MIN", "MAX", "AVG":
+		// OK
+	default:
+		return "", fmt.Errorf("unsupported aggregate function '%s'", a.Func)
+	}
+
+	param := a.Param
+	if param == "*" {
+		if fn != "COUNT" {
+			return "", fmt.Errorf("'*' is not allowed in %s", fn)
+		}
+	} else if _, exist := fieldMap[param]; !exist {
+		return "", fmt.Errorf("field '%s' not recognized", param)
+	} else {
+		param = quoteField(param)
+	}
+
+	as := a.As
+	if as == "" {
+		if a.Param == "*" {
+			as = strings.ToLower(fn)
+		} else {
+			as = strings.ToLower(fn) + "_" + a.Param
+		}
+	}
+
+	return fmt.Sprintf("%s(%s) AS `%s`", fn, param, as), nil
+}
+
+// ========
+
+// Count returns the number of records matching given conditions
+func (d *xdb) Count(prototype interface{}, args ...interface{}) (int64, error) {
+	return d.count(context.Background(), d.db, prototype, args...)
+}
+
+// CountContext is the same as Count, with a context.
+func (d *xdb) CountContext(ctx context.Context, prototype interface{}, args ...interface{}) (int64, error) {
+	return d.count(ctx, d.db, prototype, args...)
+}
+
+func (d *xdb) count(ctx context.Context, obj sqlObj, prototype interface{}, args ...interface{}) (int64, error) {
+	prototype, err := getStructPrototype(prototype)
+	if err != nil {
+		return 0, err
+	}
+
+	parsedArgs, err := d.handleArgs(prototype, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(parsedArgs.GroupList) > 0 || len(parsedArgs.HavingList) > 0 {
+		return 0, fmt.Errorf("GROUP BY is not supported in Count, please use Aggregate instead")
+	}
+	if err = checkLockingRead(obj, parsedArgs); err != nil {
+		return 0, err
+	}
+
+	// ORDER BY and LIMIT make no sense in counting
+	parsedArgs.OrderList = nil
+	parsedArgs.OrderArgs = nil
+	parsedArgs.Limit = 0
+	parsedArgs.Offset = 0
+
+	query, queryArgs := packSelectQuery("COUNT(*)", parsedArgs)
+	if parsedArgs.Opt.DoNotExec {
+		return 0, newErrorWithArgs(doNotExec, query, queryArgs)
+	}
+
+	var res []int64
+	err = obj.SelectContext(ctx, &res, query, queryArgs...)
+	if err != nil {
+		return 0, newErrorWithArgs(err.Error(), query, queryArgs)
+	}
+	if 0 == len(res) {
+		return 0, nil
+	}
+	return res[0], nil
+}
+
+// Aggregate executes a SELECT statement with aggregate functions and GROUP BY statement, and then scans results
+// into dst, which should be a pointer to a slice of structures. Selected fields are the ones in GroupBy arguments,
+// followed by the Aggregation arguments.
+func (d *xdb) Aggregate(prototype interface{}, dst interface{}, args ...interface{}) error {
+	return d.aggregate(context.Background(), d.db, prototype, dst, args...)
+}
+
+// AggregateContext is the same as Aggregate, with a context.
+func (d *xdb) AggregateContext(ctx context.Context, prototype interface{}, dst interface{}, args ...interface{}) error {
+	return d.aggregate(ctx, d.db, prototype, dst, args...)
+}
+
+func (d *xdb) aggregate(
+	ctx context.Context, obj sqlObj, prototype interface{}, dst interface{}, args ...interface{},
+) error {
+	prototype, err := getStructPrototype(prototype)
+	if err != nil {
+		return err
+	}
+
+	parsedArgs, err := d.handleArgs(prototype, args)
+	if err != nil {
+		return err
+	}
+	if 0 == len(parsedArgs.Aggregations) {
+		return fmt.Errorf("no aggregate function given")
+	}
+	if err = checkLockingRead(obj, parsedArgs); err != nil {
+		return err
+	}
+
+	fields := make([]string, 0, len(parsedArgs.GroupList)+len(parsedArgs.Aggregations))
+	for _, f := range parsedArgs.GroupList {
+		if _, exist := parsedArgs.FieldMap[f]; !exist {
+			return fmt.Errorf("field '%s' not recognized", f)
+		}
+		fields = append(fields, quoteField(f))
+	}
+	for _, agg := range parsedArgs.Aggregations {
+		s, err := agg.pack(parsedArgs.FieldMap)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, s)
+	}
+
+	query, queryArgs := packSelectQuery(strings.Join(fields, ", "), parsedArgs)
+	if parsedArgs.Opt.DoNotExec {
+		return newErrorWithArgs(doNotExec, query, queryArgs)
+	}
+
+	err = obj.SelectContext(ctx, dst, query, queryArgs...)
+	if err != nil {
+		return newErrorWithArgs(err.Error(), query, queryArgs)
+	}
+	return nil
+}
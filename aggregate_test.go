@@ -0,0 +1,100 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+)
+
+type genderStat struct {
+	Gender  string `db:"gender"`
+	Count   int64  `db:"count"`
+	MaxMask uint64 `db:"max_mask"`
+}
+
+func TestAggregate(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	err = db.CreateTable(User{})
+	if err != nil {
+		t.Errorf("CreateTable error: %v", err)
+		return
+	}
+
+	_, err = db.Count(User{}, Condition("gender", "=", "Male"), Options{DoNotExec: true})
+	t.Logf("count statement: %s", GetQueryFromError(err))
+	if !strings.Contains(GetQueryFromError(err), "SELECT COUNT(*) FROM `t_user`") {
+		t.Errorf("unexpected count statement")
+		return
+	}
+
+	cnt, err := db.Count(User{}, Condition("gender", "=", "Male"))
+	if err != nil {
+		t.Errorf("Count error: %v", err)
+		return
+	}
+	t.Logf("got %d male user(s)", cnt)
+
+	var stats []*genderStat
+	args := []interface{}{
+		GroupBy{"gender"},
+		Count("*", ""),
+		Max("status_masks", "max_mask"),
+		Having{Condition("count", ">", 0)},
+		&Order{Param: "count", Seq: "DESC"},
+	}
+	err = db.Aggregate(User{}, &stats, append(args, Options{DoNotExec: true})...)
+	t.Logf("aggregate statement: %s", GetQueryFromError(err))
+
+	err = db.Aggregate(User{}, &stats, args...)
+	if err != nil {
+		t.Errorf("Aggregate error: %v", err)
+		return
+	}
+	for _, s := range stats {
+		t.Logf("%+v", s)
+	}
+
+	// invalid parameters
+	err = db.Aggregate(User{}, &stats, Sum("*", "total"))
+	if err == nil {
+		t.Errorf("error expected for SUM(*)")
+		return
+	}
+	err = db.Aggregate(User{}, &stats, GroupBy{"gender"})
+	if err == nil {
+		t.Errorf("error expected for no aggregation")
+		return
+	}
+}
+
+func TestCountWithOrderExpression(t *testing.T) {
+	db, err := Open(Param{
+		User:           "travis",
+		DBName:         "db_test",
+		UsePlaceholder: true,
+	})
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	// arguments of ORDER BY should be dropped together with the clause
+	_, err = db.Count(
+		User{}, Condition("gender", "=", "Male"), OrderByExpr(Expr("FIELD(`id`, ?)", 1), "ASC"),
+		Options{DoNotExec: true},
+	)
+	query, args := GetQueryFromError(err), GetArgsFromError(err)
+	t.Logf("statement: %s, args: %v", query, args)
+	if query != "SELECT COUNT(*) FROM `t_user` WHERE `gender` = ?" {
+		t.Errorf("unexpected statement")
+		return
+	}
+	if len(args) != 1 || args[0] != "Male" {
+		t.Errorf("unexpected args: %v", args)
+		return
+	}
+}
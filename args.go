@@ -13,30 +13,81 @@ type _parsedArgs struct {
 	Offset    int
 	Limit     int
 	CondList  []string
+	CondArgs  []interface{}
 	OrderList []string
-	forUpdate bool
+	OrderArgs []interface{}
+	lock      string
+
+	GroupList    []string
+	HavingList   []string
+	HavingArgs   []interface{}
+	Aggregations []*Aggregation
+	Columns      []string
+
+	Joins []*Join
+	from  string
+
+	IndexHints     []*IndexHint
+	OptimizerHints []*OptimizerHintType
+	straightJoin   bool
+
+	unscoped    bool
+	hardDelete  bool
+	softDelete  *Field
+	requireCond bool
 }
 
 func (d *xdb) handleArgs(prototype interface{}, args []interface{}) (ret *_parsedArgs, err error) {
+	fieldMap, err := d.getFieldMap(prototype)
+	if err != nil {
+		return
+	}
+	ret, err = d.parseArgs(prototype, fieldMap, args)
+	if err != nil {
+		return
+	}
+	if len(ret.Joins) > 0 {
+		return nil, fmt.Errorf("JOIN is only supported in Select")
+	}
+	if ret.requireCond && len(ret.CondList) == 0 {
+		return nil, fmt.Errorf("no valid conditions given")
+	}
+
+	// filter soft deleted records
+	ret.softDelete = d.softDeleteField(prototype)
+	if ret.softDelete != nil && !ret.unscoped {
+		ret.CondList = append(ret.CondList, notDeletedCond(ret.softDelete, ret.softDelete.Name))
+	}
+	return
+}
+
+// parseArgs parses arguments with given field map. Options will be merged with given prototype.
+func (d *xdb) parseArgs(
+	prototype interface{}, fieldMap map[string]*Field, args []interface{},
+) (ret *_parsedArgs, err error) {
 	ret = &_parsedArgs{
 		CondList:  make([]string, 0, len(args)),
 		OrderList: make([]string, 0, len(args)),
 	}
 
-	ret.FieldMap, err = d.getFieldMap(prototype)
-	if err != nil {
-		return
-	}
+	ret.FieldMap = fieldMap
 	ret.Opt = mergeOptions(prototype)
+	b := d.newBinder()
+	hb := d.newBinder()
+	ob := d.newBinder()
 
 	for _, arg := range args {
 		c := ""
 		o := ""
 		switch arg.(type) {
 		default:
-			t := reflect.TypeOf(arg)
-			err = fmt.Errorf("unsupported type %v", t)
-			return
+			var ok bool
+			c, ok = packCondition(arg, ret.FieldMap, b)
+			if !ok {
+				t := reflect.TypeOf(arg)
+				err = fmt.Errorf("unsupported type %v", t)
+				return
+			}
 		case *Options:
 			ret.Opt = mergeOptions(prototype, *(arg.(*Options)))
 		case Options:
@@ -45,34 +96,77 @@ func (d *xdb) handleArgs(prototype interface{}, args []interface{}) (ret *_parse
 			ret.Limit = int(arg.(Limit))
 		case Offset:
 			ret.Offset = int(arg.(Offset))
-		case Cond:
-			cond := arg.(Cond)
-			c = cond.pack(ret.FieldMap)
-		case *Cond:
-			cond := arg.(*Cond)
-			c = cond.pack(ret.FieldMap)
-		case And:
-			and := arg.(And)
-			c = and.pack(ret.FieldMap)
-		case *And:
-			and := arg.(*And)
-			c = and.pack(ret.FieldMap)
-		case Or:
-			or := arg.(Or)
-			c = or.pack(ret.FieldMap)
-		case *Or:
-			or := arg.(*Or)
-			c = or.pack(ret.FieldMap)
 		case Order:
 			order := arg.(Order)
 			o = order.pack()
 		case *Order:
 			order := arg.(*Order)
 			o = order.pack()
+		case OrderExpr:
+			order := arg.(OrderExpr)
+			o = order.pack(ret.FieldMap, ob)
+		case *OrderExpr:
+			o = arg.(*OrderExpr).pack(ret.FieldMap, ob)
 		case *ForUpdateType:
-			ret.forUpdate = true
+			ret.lock, err = arg.(*ForUpdateType).pack()
+			if err != nil {
+				return
+			}
 		case ForUpdateType:
-			ret.forUpdate = true
+			f := arg.(ForUpdateType)
+			ret.lock, err = f.pack()
+			if err != nil {
+				return
+			}
+		case GroupBy:
+			ret.GroupList = append(ret.GroupList, arg.(GroupBy).fields()...)
+		case Having:
+			if h := arg.(Having).pack(ret.FieldMap, hb); h != "" {
+				ret.HavingList = append(ret.HavingList, h)
+			}
+		case *Having:
+			if h := arg.(*Having).pack(ret.FieldMap, hb); h != "" {
+				ret.HavingList = append(ret.HavingList, h)
+			}
+		case ColumnsType:
+			ret.Columns = append(ret.Columns, arg.(ColumnsType)...)
+		case *ColumnsType:
+			ret.Columns = append(ret.Columns, *(arg.(*ColumnsType))...)
+		case Join:
+			join := arg.(Join)
+			ret.Joins = append(ret.Joins, &join)
+		case *Join:
+			ret.Joins = append(ret.Joins, arg.(*Join))
+		case IndexHint:
+			hint := arg.(IndexHint)
+			if _, err = hint.pack(); err != nil {
+				return
+			}
+			ret.IndexHints = append(ret.IndexHints, &hint)
+		case *IndexHint:
+			if _, err = arg.(*IndexHint).pack(); err != nil {
+				return
+			}
+			ret.IndexHints = append(ret.IndexHints, arg.(*IndexHint))
+		case OptimizerHintType:
+			hint := arg.(OptimizerHintType)
+			ret.OptimizerHints = append(ret.OptimizerHints, &hint)
+		case *OptimizerHintType:
+			ret.OptimizerHints = append(ret.OptimizerHints, arg.(*OptimizerHintType))
+		case StraightJoinType, *StraightJoinType:
+			ret.straightJoin = true
+		case UnscopedType, *UnscopedType:
+			ret.unscoped = true
+		case HardDeleteType, *HardDeleteType:
+			ret.unscoped = true
+			ret.hardDelete = true
+		case requireCondType:
+			ret.requireCond = true
+		case Aggregation:
+			agg := arg.(Aggregation)
+			ret.Aggregations = append(ret.Aggregations, &agg)
+		case *Aggregation:
+			ret.Aggregations = append(ret.Aggregations, arg.(*Aggregation))
 		}
 
 		if "" != c {
@@ -83,6 +177,19 @@ func (d *xdb) handleArgs(prototype interface{}, args []interface{}) (ret *_parse
 		}
 	}
 
+	if b.err != nil {
+		return nil, b.err
+	}
+	if hb.err != nil {
+		return nil, hb.err
+	}
+	if ob.err != nil {
+		return nil, ob.err
+	}
+	ret.CondArgs = b.args
+	ret.HavingArgs = hb.args
+	ret.OrderArgs = ob.args
+
 	if "" == ret.Opt.TableName {
 		err = fmt.Errorf("nil table name")
 		return
@@ -440,3 +440,44 @@ func TestSpecifyingAutoIncrementID(t *testing.T) {
 
 	return
 }
+
+type fspTimeRecord struct {
+	ID        int64     `db:"f_id"          mysqlx:"increment:true"`
+	Datetime  time.Time `db:"f_datetime"    mysqlx:"type:datetime(3)"`
+	Timestamp time.Time `db:"f_timestamp"   mysqlx:"type:timestamp(6)"`
+}
+
+func (fspTimeRecord) Options() Options {
+	return Options{
+		TableName: "t_mysqlx_fsp_time_test",
+	}
+}
+
+func TestFractionalSecondTime(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	r := fspTimeRecord{Datetime: tm, Timestamp: tm}
+	_, err = d.Insert(&r, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "INSERT INTO `t_mysqlx_fsp_time_test` (`f_datetime`, `f_timestamp`) "+
+		"VALUES ('2020-01-02 03:04:05.123', '2020-01-02 03:04:05.123456')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = d.Update(
+		&r, map[string]interface{}{"f_datetime": tm}, Condition("f_id", "=", 1), Options{DoNotExec: true},
+	)
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_fsp_time_test` SET `f_datetime` = '2020-01-02 03:04:05.123' WHERE `f_id` = 1" {
+		t.Errorf("unexpected statement")
+		return
+	}
+}
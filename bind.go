@@ -0,0 +1,48 @@
+package mysqlx
+
+import "strings"
+
+// binder collects arguments for '?' placeholders while a statement is being packed. If placeholder mode is not
+// enabled, values are written into the statement as escaped literals, which is the default behavior of mysqlx.
+type binder struct {
+	placeholder bool
+	args        []interface{}
+
+	// db is used to pack nested statements, such as sub-queries
+	db *xdb
+	// err records the first error in nested statements
+	err error
+}
+
+func (d *xdb) newBinder() *binder {
+	return &binder{
+		placeholder: d.param.UsePlaceholder,
+		db:          d,
+	}
+}
+
+// bind returns "?" and records arg in placeholder mode. Otherwise, the literal is returned.
+func (b *binder) bind(literal string, arg interface{}) string {
+	if nil == b || false == b.placeholder {
+		return literal
+	}
+	b.args = append(b.args, arg)
+	return "?"
+}
+
+// fail records the first error in packing statements
+func (b *binder) fail(err error) error {
+	if b != nil && b.err == nil {
+		b.err = err
+	}
+	return err
+}
+
+// bindTime binds a time literal generated by convTimeToString. The driver would convert time.Time values with
+// its own location settings, therefore the formatted string is bound instead.
+func (b *binder) bindTime(literal string) string {
+	if literal == "NULL" {
+		return b.bind(literal, nil)
+	}
+	return b.bind(literal, strings.Trim(literal, "'"))
+}
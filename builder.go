@@ -0,0 +1,245 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Sequences used in ORDER BY statements
+const (
+	Asc  = "ASC"
+	Desc = "DESC"
+)
+
+// Query is a chainable query builder returned by From. Arguments are checked once they are given, and the first
+// error is returned by the terminal methods, such as Find, Update, Delete, Count and SQL. A Query could not be
+// shared across goroutines.
+type Query struct {
+	ctx       context.Context
+	d         *xdb
+	obj       sqlObj
+	prototype interface{}
+	fieldMap  map[string]*Field
+	args      []interface{}
+	err       error
+}
+
+// From starts a chainable query with given table structure prototype
+func (d *xdb) From(prototype interface{}) *Query {
+	return d.from(d.db, prototype)
+}
+
+func (d *xdb) from(obj sqlObj, prototype interface{}) *Query {
+	q := &Query{
+		ctx: context.Background(),
+		d:   d,
+		obj: obj,
+	}
+	q.prototype, q.err = getStructPrototype(prototype)
+	if q.err != nil {
+		return q
+	}
+	q.fieldMap, q.err = d.getFieldMap(q.prototype)
+	return q
+}
+
+// Context sets the context used in terminal methods
+func (q *Query) Context(ctx context.Context) *Query {
+	q.ctx = ctx
+	return q
+}
+
+// Where adds a condition with AND logic, the same as Condition(param, operator, value)
+func (q *Query) Where(param, operator string, value interface{}) *Query {
+	return q.WhereCond(Condition(param, operator, value))
+}
+
+// And is the same as Where
+func (q *Query) And(param, operator string, value interface{}) *Query {
+	return q.Where(param, operator, value)
+}
+
+// WhereCond adds conditions with AND logic. Only Cond, *Cond, And, Or and Exists types are acceptable.
+func (q *Query) WhereCond(conds ...interface{}) *Query {
+	if q.err != nil {
+		return q
+	}
+	for _, c := range conds {
+		if q.err = q.d.checkCondition(c, q.fieldMap); q.err != nil {
+			return q
+		}
+	}
+	q.args = append(q.args, conds...)
+	return q
+}
+
+// OrderBy adds a field into ORDER BY statement. seq should be Asc or Desc.
+func (q *Query) OrderBy(param, seq string) *Query {
+	if q.err != nil {
+		return q
+	}
+	if _, exist := q.fieldMap[param]; !exist {
+		q.err = fmt.Errorf("field '%s' not recognized", param)
+		return q
+	}
+	seq = strings.ToUpper(seq)
+	if seq != Asc && seq != Desc {
+		q.err = fmt.Errorf("invalid order sequence '%s'", seq)
+		return q
+	}
+	q.args = append(q.args, &Order{Param: param, Seq: seq})
+	return q
+}
+
+// Limit sets LIMIT statement
+func (q *Query) Limit(limit int) *Query {
+	if q.err == nil && limit < 0 {
+		q.err = fmt.Errorf("invalid limit %d", limit)
+	}
+	q.args = append(q.args, Limit(limit))
+	return q
+}
+
+// Offset sets OFFSET statement
+func (q *Query) Offset(offset int) *Query {
+	if q.err == nil && offset < 0 {
+		q.err = fmt.Errorf("invalid offset %d", offset)
+	}
+	q.args = append(q.args, Offset(offset))
+	return q
+}
+
+// Columns limits selected fields in Find
+func (q *Query) Columns(fields ...string) *Query {
+	if q.err != nil {
+		return q
+	}
+	if _, q.err = ColumnsType(fields).pack(q.fieldMap); q.err != nil {
+		return q
+	}
+	q.args = append(q.args, Columns(fields...))
+	return q
+}
+
+// Options sets additional options
+func (q *Query) Options(opt Options) *Query {
+	q.args = append(q.args, opt)
+	return q
+}
+
+// With adds other arguments which are acceptable by Select, such as ForUpdate() and index hints.
+func (q *Query) With(args ...interface{}) *Query {
+	q.args = append(q.args, args...)
+	return q
+}
+
+// Find selects records into dst, which should be a pointer to a slice of the prototype structure or its pointers.
+func (q *Query) Find(dst interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	ty, err := getSliceElemStructType(dst)
+	if err != nil {
+		return err
+	}
+	if ty != reflect.TypeOf(q.prototype) {
+		return fmt.Errorf("destination type %v mismatches prototype %T", ty, q.prototype)
+	}
+	return q.d.selectFunc(q.ctx, q.obj, dst, q.args...)
+}
+
+// Get selects the first record into dst, which should be a pointer to the prototype structure. If no record
+// matches, sql.ErrNoRows will be returned.
+func (q *Query) Get(dst interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	if ty := reflect.TypeOf(dst); ty == nil || ty.Kind() != reflect.Ptr || ty.Elem() != reflect.TypeOf(q.prototype) {
+		return fmt.Errorf("destination type %v mismatches prototype %T", ty, q.prototype)
+	}
+	return q.d.get(q.ctx, q.obj, dst, q.args...)
+}
+
+// Update updates fields of matched records
+func (q *Query) Update(fields map[string]interface{}) (sql.Result, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return q.d.update(q.ctx, q.obj, q.prototype, fields, q.args...)
+}
+
+// Delete deletes matched records
+func (q *Query) Delete() (sql.Result, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return q.d.delete(q.ctx, q.obj, q.prototype, q.args...)
+}
+
+// Count returns the number of matched records
+func (q *Query) Count() (int64, error) {
+	if q.err != nil {
+		return 0, q.err
+	}
+	return q.d.count(q.ctx, q.obj, q.prototype, q.args...)
+}
+
+// SQL returns the SELECT statement and its arguments which would be executed by Find, without executing it.
+func (q *Query) SQL() (string, []interface{}, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+	query, args, _, err := q.d.packSelect(q.ctx, q.obj, reflect.TypeOf(q.prototype), q.args)
+	if err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
+
+// checkCondition checks if a condition is valid, including nested ones. Invalid conditions are ignored by
+// packCondition, while they are reported here.
+func (d *xdb) checkCondition(v interface{}, fieldMap map[string]*Field) error {
+	switch c := v.(type) {
+	default:
+		return fmt.Errorf("unsupported condition type %T", v)
+	case Cond:
+		return d.checkCondition(&c, fieldMap)
+	case *Cond:
+		if nil == c {
+			return fmt.Errorf("nil condition")
+		}
+		if _, exist := fieldMap[c.Param]; !exist {
+			return fmt.Errorf("field '%s' not recognized", c.Param)
+		}
+		copied := *c
+		b := d.newBinder()
+		if _, _, _, err := copied.parse(fieldMap, b); err != nil {
+			return err
+		}
+		return b.err
+	case And:
+		return d.checkConditions(c, fieldMap)
+	case *And:
+		return d.checkConditions(*c, fieldMap)
+	case Or:
+		return d.checkConditions(c, fieldMap)
+	case *Or:
+		return d.checkConditions(*c, fieldMap)
+	case *ExistsType:
+		b := d.newBinder()
+		c.pack(b)
+		return b.err
+	}
+}
+
+func (d *xdb) checkConditions(conds []interface{}, fieldMap map[string]*Field) error {
+	for _, c := range conds {
+		if err := d.checkCondition(c, fieldMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+	d.MustCreateTable(&txTestRecord{})
+
+	_, err = d.InsertMany([]txTestRecord{{String: "builder"}, {String: "builder"}, {String: "builder"}})
+	if err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+
+	query, _, err := d.From(&txTestRecord{}).Where("f_string", "=", "builder").OrderBy("f_id", Desc).Limit(2).SQL()
+	if err != nil {
+		t.Errorf("SQL error: %v", err)
+		return
+	}
+	t.Logf("statement: %s", query)
+	if !strings.HasSuffix(query, "WHERE `f_string` = 'builder' ORDER BY `f_id` DESC LIMIT 2") {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	var list []*txTestRecord
+	err = d.From(&txTestRecord{}).Where("f_string", "=", "builder").OrderBy("f_id", Desc).Limit(2).Find(&list)
+	if err != nil {
+		t.Errorf("Find error: %v", err)
+		return
+	}
+	if len(list) != 2 || list[0].ID < list[1].ID {
+		t.Errorf("unexpected records: %+v", list)
+		return
+	}
+
+	cnt, err := d.From(txTestRecord{}).Where("f_string", "=", "builder").And("f_id", "<=", list[0].ID).Count()
+	if err != nil {
+		t.Errorf("Count error: %v", err)
+		return
+	}
+	if cnt < 3 {
+		t.Errorf("at least 3 records expected, but got %d", cnt)
+		return
+	}
+
+	_, err = d.From(txTestRecord{}).Where("f_id", "=", list[0].ID).Update(map[string]interface{}{"f_string": "builder updated"})
+	if err != nil {
+		t.Errorf("Update error: %v", err)
+		return
+	}
+	_, err = d.From(txTestRecord{}).Where("f_string", "=", "builder updated").Delete()
+	if err != nil {
+		t.Errorf("Delete error: %v", err)
+		return
+	}
+
+	// invalid conditions should be reported
+	err = d.From(txTestRecord{}).Where("f_not_exist", "=", 1).Find(&list)
+	if err == nil {
+		t.Errorf("error expected for unknown field")
+		return
+	}
+	_, err = d.From(txTestRecord{}).Where("f_id", "in", []int{}).Delete()
+	if err == nil {
+		t.Errorf("error expected for empty IN values")
+		return
+	}
+	t.Logf("expected error: %v", err)
+}
@@ -0,0 +1,29 @@
+package mysqlx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColumnsType is returned by Columns()
+type ColumnsType []string
+
+// Columns is used in Select to limit selected fields. Other fields in the destination structure will be kept as
+// zero values.
+func Columns(fields ...string) ColumnsType {
+	return ColumnsType(fields)
+}
+
+func (c ColumnsType) pack(fieldMap map[string]*Field) (string, error) {
+	fieldNames := make([]string, 0, len(c))
+	for _, f := range c {
+		if _, exist := fieldMap[f]; !exist {
+			return "", fmt.Errorf("field '%s' not recognized", f)
+		}
+		fieldNames = append(fieldNames, "`"+f+"`")
+	}
+	if 0 == len(fieldNames) {
+		return "", fmt.Errorf("no columns given")
+	}
+	return strings.Join(fieldNames, ", "), nil
+}
@@ -0,0 +1,48 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectColumns(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	err = db.CreateTable(User{})
+	if err != nil {
+		t.Errorf("CreateTable error: %v", err)
+		return
+	}
+
+	var res []*User
+	err = db.Select(&res, Columns("id", "full_name"), Limit(10), Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.HasPrefix(query, "SELECT `id`, `full_name` FROM `t_user`") {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	err = db.Select(&res, Columns("id", "full_name"), Limit(10))
+	if err != nil {
+		t.Errorf("Select error: %v", err)
+		return
+	}
+	for _, u := range res {
+		if u.Gender != "" || u.Nationality != "" {
+			t.Errorf("unselected fields should be zero: %+v", u)
+			return
+		}
+	}
+
+	err = db.Select(&res, Columns("id", "no_such_field"))
+	if err == nil {
+		t.Errorf("error expected for unknown column")
+		return
+	}
+	t.Logf("expected error: %v", err)
+}
@@ -24,7 +24,8 @@ func Condition(param, operator string, value interface{}) *Cond {
 	}
 }
 
-// Like return Cond data with LIKE operator in function format
+// Like return Cond data with LIKE operator in function format. '%' and '\' in parts are escaped, and parts are
+// joined with '%' as the LIKE pattern string in Value.
 func Like(param string, likeParts []string) *Cond {
 	if 0 == len(likeParts) {
 		return nil
@@ -37,7 +38,7 @@ func Like(param string, likeParts []string) *Cond {
 
 	parts := make([]string, 0, len(likeParts))
 	for _, p := range likeParts {
-		parts = append(parts, escapeLikeValueString(p))
+		parts = append(parts, escapeLikePattern(p))
 	}
 
 	c.Value = strings.Join(parts, "%")
@@ -45,27 +46,53 @@ func Like(param string, likeParts []string) *Cond {
 }
 
 // parseCondIn is invoked by parseCond. This handles sutiations those the operator is "in".
-func (c *Cond) parseIn(fieldMap map[string]*Field) (field, operator, value string, err error) {
+func (c *Cond) parseIn(fieldMap map[string]*Field, b *binder) (field, operator, value string, err error) {
 	operator = "IN"
 	field = c.Param
 
+	var values []string
 	switch c.Value.(type) {
 	default:
-		err = fmt.Errorf("invalid condition value type following by '%s'", c.Operator)
+		// slices of named types, such as []ID where ID is defined as int64
+		va := reflect.ValueOf(c.Value)
+		if va.Kind() != reflect.Slice && va.Kind() != reflect.Array {
+			err = fmt.Errorf("invalid condition value type following by '%s'", c.Operator)
+			return
+		}
+		in := make([]interface{}, 0, va.Len())
+		for i := 0; i < va.Len(); i++ {
+			in = append(in, va.Index(i).Interface())
+		}
+		values, err = packInValues(in, fieldMap, b)
+		if err != nil {
+			b.fail(fmt.Errorf("IN values of field '%s' error: %w", c.Param, err))
+			return
+		}
+	case *SubQueryType:
+		value, err = c.Value.(*SubQueryType).pack(b, false)
+		if err != nil {
+			b.fail(fmt.Errorf("sub-query of field '%s' error: %w", c.Param, err))
+		}
 		return
 	case []int, []uint, []int8, []uint8, []int16, []uint16, []int32, []uint32, []int64, []uint64, []float32, []float64:
-		s := fmt.Sprintf("%+v", c.Value)
-		s = strings.Replace(s, " ", ", ", -1)
-		s = strings.Replace(s, "[", "(", -1)
-		s = strings.Replace(s, "]", ")", -1)
-		value = s
+		va := reflect.ValueOf(c.Value)
+		values = make([]string, 0, va.Len())
+		for i := 0; i < va.Len(); i++ {
+			n := va.Index(i).Interface()
+			values = append(values, b.bind(fmt.Sprintf("%v", n), n))
+		}
 	case []string:
 		in := c.Value.([]string)
-		out := make([]string, 0, len(in))
+		values = make([]string, 0, len(in))
 		for _, s := range in {
-			out = append(out, addQuoteToString(s, "'"))
+			values = append(values, b.bind(addQuoteToString(escapeValueString(s), "'"), s))
+		}
+	case []interface{}:
+		values, err = packInValues(c.Value.([]interface{}), fieldMap, b)
+		if err != nil {
+			b.fail(fmt.Errorf("IN values of field '%s' error: %w", c.Param, err))
+			return
 		}
-		value = "(" + strings.Join(out, ", ") + ")"
 	case []time.Time:
 		in := c.Value.([]time.Time)
 		_, exist := fieldMap[c.Param]
@@ -73,21 +100,34 @@ func (c *Cond) parseIn(fieldMap map[string]*Field) (field, operator, value strin
 			err = fmt.Errorf("field '%s' not found", c.Param)
 			return
 		}
-		values := make([]string, 0, len(in))
+		values = make([]string, 0, len(in))
 		for _, t := range in {
-			values = append(values, convTimeToString(t, fieldMap, c.Param))
+			values = append(values, b.bindTime(convTimeToString(t, fieldMap, c.Param)))
 		}
-		value = "(" + strings.Join(values, ", ") + ")"
 	}
 
-	if value == "()" {
+	if 0 == len(values) {
 		err = fmt.Errorf("empty values in field '%s'", field)
 		return
 	}
+	value = "(" + strings.Join(values, ", ") + ")"
 	return
 }
 
-func (c *Cond) parse(fieldMap map[string]*Field) (field, operator, value string, err error) {
+// packInValues packs values of an IN list one by one
+func packInValues(in []interface{}, fieldMap map[string]*Field, b *binder) ([]string, error) {
+	values := make([]string, 0, len(in))
+	for _, v := range in {
+		s, err := packExpressionArg(v, fieldMap, b)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+func (c *Cond) parse(fieldMap map[string]*Field, b *binder) (field, operator, value string, err error) {
 	// param
 	if c.Param == "" {
 		err = fmt.Errorf("nil param name")
@@ -106,20 +146,22 @@ func (c *Cond) parse(fieldMap map[string]*Field) (field, operator, value string,
 	case "IS NOT", "is not", "not", "NOT":
 		c.Operator = "IS NOT"
 	case "in", "IN":
-		return c.parseIn(fieldMap)
+		return c.parseIn(fieldMap, b)
 	case "not in", "NOT IN":
-		field, _, value, err = c.parseIn(fieldMap)
+		field, _, value, err = c.parseIn(fieldMap, b)
 		operator = "NOT IN"
 		return
 	case "LIKE", "like":
-		v, ok := c.Value.(string)
-		if false == ok {
+		switch v := c.Value.(type) {
+		default:
 			err = fmt.Errorf("LIKE value should be raw string")
 			return
+		case string:
+			// v is a LIKE pattern, which is escaped as a string value here
+			value = b.bind(addQuoteToString(escapeValueString(v), "'"), v)
 		}
 		field = c.Param
 		operator = "LIKE"
-		value = "'" + v + "'"
 		return
 	default:
 		err = fmt.Errorf("invalid operator '%s'", c.Operator)
@@ -133,23 +175,22 @@ func (c *Cond) parse(fieldMap map[string]*Field) (field, operator, value string,
 		return
 	case int, int64, int32, int16, int8:
 		n := reflect.ValueOf(c.Value).Int()
-		value = strconv.FormatInt(n, 10)
+		value = b.bind(strconv.FormatInt(n, 10), n)
 	case uint, uint64, uint32, uint16, uint8:
 		n := reflect.ValueOf(c.Value).Uint()
-		value = strconv.FormatUint(n, 10)
+		value = b.bind(strconv.FormatUint(n, 10), n)
 	case bool:
 		if c.Value.(bool) {
-			value = "TRUE"
+			value = b.bind("TRUE", true)
 		} else {
-			value = "FALSE"
+			value = b.bind("FALSE", false)
 		}
 	case float32, float64:
 		f := reflect.ValueOf(c.Value).Float()
-		value = fmt.Sprintf("%f", f)
+		value = b.bind(fmt.Sprintf("%f", f), f)
 	case string:
 		s := c.Value.(string)
-		s = escapeValueString(s)
-		value = addQuoteToString(s, "'")
+		value = b.bind(addQuoteToString(escapeValueString(s), "'"), s)
 	case time.Time:
 		t := c.Value.(time.Time)
 		_, exist := fieldMap[c.Param]
@@ -157,7 +198,26 @@ func (c *Cond) parse(fieldMap map[string]*Field) (field, operator, value string,
 			err = fmt.Errorf("field '%s' not found", c.Param)
 			return
 		}
-		value = convTimeToString(t, fieldMap, c.Param)
+		value = b.bindTime(convTimeToString(t, fieldMap, c.Param))
+	case ColumnRef:
+		// errors in field references and expressions are reported rather than ignored
+		value, err = c.Value.(ColumnRef).pack(fieldMap)
+		if err != nil {
+			b.fail(err)
+			return
+		}
+	case Expression, *Expression:
+		e, ok := c.Value.(*Expression)
+		if !ok {
+			expr := c.Value.(Expression)
+			e = &expr
+		}
+		value, err = e.pack(fieldMap, b)
+		if err != nil {
+			b.fail(err)
+			return
+		}
+		value = "(" + value + ")"
 	case nil:
 		switch c.Operator {
 		case "=", "==":
@@ -176,11 +236,46 @@ func (c *Cond) parse(fieldMap map[string]*Field) (field, operator, value string,
 	return
 }
 
-func (c *Cond) pack(fieldMap map[string]*Field) string {
-	field, operator, value, err := c.parse(fieldMap)
+// packCondition packs a condition type, such as Cond, And, Or and their pointers. ok is false if v is not a
+// condition type.
+func packCondition(v interface{}, fieldMap map[string]*Field, b *binder) (s string, ok bool) {
+	switch c := v.(type) {
+	default:
+		return "", false
+	case Cond:
+		return c.pack(fieldMap, b), true
+	case *Cond:
+		return c.pack(fieldMap, b), true
+	case And:
+		return c.pack(fieldMap, b), true
+	case *And:
+		return c.pack(fieldMap, b), true
+	case Or:
+		return c.pack(fieldMap, b), true
+	case *Or:
+		return c.pack(fieldMap, b), true
+	case *ExistsType:
+		return c.pack(b), true
+	case *rowCond:
+		return c.pack(fieldMap, b), true
+	}
+}
+
+// isConditionArg tells whether given argument is a condition acceptable by packCondition
+func isConditionArg(v interface{}) bool {
+	switch v.(type) {
+	case Cond, *Cond, And, *And, Or, *Or, *ExistsType, *rowCond:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Cond) pack(fieldMap map[string]*Field, b *binder) string {
+	field, operator, value, err := c.parse(fieldMap, b)
 	if err != nil {
 		return ""
 	}
 
-	return fmt.Sprintf("`%s` %s %s", field, operator, value)
+	return fmt.Sprintf("%s %s %s", quoteField(field), operator, value)
 }
@@ -0,0 +1,63 @@
+package mysqlx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = d.CreateTableContext(ctx, &txTestRecord{})
+	if err != nil {
+		t.Errorf("CreateTableContext error: %v", err)
+		return
+	}
+
+	r := txTestRecord{String: "context"}
+	res, err := d.InsertContext(ctx, &r)
+	if err != nil {
+		t.Errorf("InsertContext error: %v", err)
+		return
+	}
+	id, _ := res.LastInsertId()
+
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		t.Errorf("BeginTx error: %v", err)
+		return
+	}
+	var list []*txTestRecord
+	err = tx.SelectContext(ctx, &list, Condition("f_id", "=", id), ForUpdate())
+	if err != nil {
+		t.Errorf("SelectContext error: %v", err)
+		tx.Rollback()
+		return
+	}
+	if len(list) != 1 {
+		t.Errorf("unexpected record count %d", len(list))
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Errorf("Commit error: %v", err)
+		return
+	}
+
+	// canceled context should stop the statement
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	err = d.SelectContext(canceled, &list, Condition("f_id", "=", id))
+	if err == nil {
+		t.Errorf("error expected with canceled context")
+		return
+	}
+	t.Logf("expected error: %v", err)
+}
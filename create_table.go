@@ -2,6 +2,7 @@ package mysqlx
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -56,7 +57,7 @@ func (d *xdb) AutoCreateTable() {
 	d.autoCreateTable.Store(true)
 }
 
-func (d *xdb) checkAutoCreateTable(v interface{}, opt Options) error {
+func (d *xdb) checkAutoCreateTable(ctx context.Context, v interface{}, opt Options) error {
 	if false == d.autoCreateTable.Load() {
 		return nil
 	}
@@ -65,7 +66,7 @@ func (d *xdb) checkAutoCreateTable(v interface{}, opt Options) error {
 		return nil
 	}
 
-	return d.CreateTable(v, opt)
+	return d.CreateTableContext(ctx, v, opt)
 }
 
 // duplicateStructAndGetOpts As we cannot directly get the address value
@@ -114,6 +115,9 @@ func mergeOptions(v interface{}, opts ...Options) Options {
 		if len(opts[0].Uniques) > 0 {
 			opt.Uniques = opts[0].Uniques
 		}
+		if len(opts[0].PrimaryKey) > 0 {
+			opt.PrimaryKey = opts[0].PrimaryKey
+		}
 		if len(opts[0].CreateTableParams) > 0 {
 			if nil == opt.CreateTableParams {
 				opt.CreateTableParams = opts[0].CreateTableParams
@@ -123,6 +127,18 @@ func mergeOptions(v interface{}, opts ...Options) Options {
 				}
 			}
 		}
+		if 0 != opts[0].MaxBatchRows {
+			opt.MaxBatchRows = opts[0].MaxBatchRows
+		}
+		if 0 != opts[0].MaxBatchBytes {
+			opt.MaxBatchBytes = opts[0].MaxBatchBytes
+		}
+		if opts[0].BatchInTx {
+			opt.BatchInTx = true
+		}
+		if "" != opts[0].Priority {
+			opt.Priority = opts[0].Priority
+		}
 		opt.DoNotExec = opts[0].DoNotExec
 	}
 	if nil == opt.Indexes {
@@ -162,7 +178,14 @@ func (d *xdb) mysqlCreateTableStatement(fields []*Field, opt *Options) (string,
 	}
 
 	// make index statements
-	if autoIncField != nil {
+	if len(opt.PrimaryKey) > 0 {
+		pkFieldList := make([]string, 0, len(opt.PrimaryKey))
+		for _, f := range opt.PrimaryKey {
+			pkFieldList = append(pkFieldList, "`"+f+"`")
+		}
+		s := fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(pkFieldList, ", "))
+		statements = append(statements, s)
+	} else if autoIncField != nil {
 		s := fmt.Sprintf("PRIMARY KEY (`%s`)", autoIncField.Name)
 		statements = append(statements, s)
 		// log.Printf("statement: %s\n", s)
@@ -323,10 +346,10 @@ func (d *xdb) mysqlAlterTableFieldsStatements(fields []*Field, fieldsInDB []*Fie
 	return ret, nil
 }
 
-func (d *xdb) mysqlAlterTableIndexUniquesStatements(opt *Options) (ret []string, err error) {
+func (d *xdb) mysqlAlterTableIndexUniquesStatements(ctx context.Context, opt *Options) (ret []string, err error) {
 	ret = []string{}
 	// read index and uniques
-	indexInDB, uniqInDB, err := d.ReadTableIndexes(opt.TableName)
+	indexInDB, uniqInDB, err := d.readTableIndexes(ctx, opt.TableName)
 	if err != nil {
 		return
 	}
@@ -376,7 +399,7 @@ func (d *xdb) mysqlAlterTableIndexUniquesStatements(opt *Options) (ret []string,
 //
 // The returned exists identifies if the table exists in database.
 func (d *xdb) CreateOrAlterTableStatements(v interface{}, opts ...Options) (exists bool, statements []string, err error) {
-	exists, create, alter, _, err := d.createAndAlterTableStatements(v, opts...)
+	exists, create, alter, _, err := d.createAndAlterTableStatements(context.Background(), v, opts...)
 	if err != nil {
 		return
 	}
@@ -388,7 +411,9 @@ func (d *xdb) CreateOrAlterTableStatements(v interface{}, opts ...Options) (exis
 	return
 }
 
-func (d *xdb) createAndAlterTableStatements(v interface{}, opts ...Options) (exists bool, create string, alter []string, opt Options, err error) {
+func (d *xdb) createAndAlterTableStatements(
+	ctx context.Context, v interface{}, opts ...Options,
+) (exists bool, create string, alter []string, opt Options, err error) {
 	if nil == d.db {
 		err = fmt.Errorf("mysqlx not initialized")
 		return
@@ -415,7 +440,7 @@ func (d *xdb) createAndAlterTableStatements(v interface{}, opts ...Options) (exi
 	// read fields and check if table exists
 	shouldCreate := false
 	// log.Println("now start readTableFields")
-	fieldsInDB, err := d.ReadTableFields(opt.TableName)
+	fieldsInDB, err := d.readTableFields(ctx, opt.TableName)
 	if err != nil {
 		if false == strings.Contains(err.Error(), "doesn't exist") {
 			return
@@ -441,7 +466,7 @@ func (d *xdb) createAndAlterTableStatements(v interface{}, opts ...Options) (exi
 		return
 	}
 	// check and alter indexes and uniques
-	alterIndexStatements, err := d.mysqlAlterTableIndexUniquesStatements(&opt)
+	alterIndexStatements, err := d.mysqlAlterTableIndexUniquesStatements(ctx, &opt)
 	if err != nil {
 		return
 	}
@@ -452,7 +477,12 @@ func (d *xdb) createAndAlterTableStatements(v interface{}, opts ...Options) (exi
 
 // CreateTable creates a table if not exist. If the table exists, it will alter it if necessary
 func (d *xdb) CreateTable(v interface{}, opts ...Options) error {
-	exists, create, alter, opt, err := d.createAndAlterTableStatements(v, opts...)
+	return d.CreateTableContext(context.Background(), v, opts...)
+}
+
+// CreateTableContext is the same as CreateTable, with a context.
+func (d *xdb) CreateTableContext(ctx context.Context, v interface{}, opts ...Options) error {
+	exists, create, alter, opt, err := d.createAndAlterTableStatements(ctx, v, opts...)
 	if err != nil {
 		return err
 	}
@@ -462,7 +492,7 @@ func (d *xdb) CreateTable(v interface{}, opts ...Options) error {
 		if opt.DoNotExec {
 			return newError(doNotExec, create)
 		}
-		_, err = d.db.Exec(create)
+		_, err = d.db.ExecContext(ctx, create)
 		if err != nil {
 			return newError(err.Error(), create)
 		}
@@ -476,7 +506,7 @@ func (d *xdb) CreateTable(v interface{}, opts ...Options) error {
 		return newError(doNotExec, strings.Join(alter, ";\n"))
 	}
 	for _, query := range alter {
-		_, err = d.db.Exec(query)
+		_, err = d.db.ExecContext(ctx, query)
 		if err != nil {
 			return newError(err.Error(), strings.Join(alter, ";\n"))
 		}
@@ -623,15 +653,46 @@ func readStructFields(t reflect.Type, v reflect.Value) (ret []*Field, err error)
 			continue
 		}
 
+		// soft delete field should be a nullable time or a boolean
+		fieldSoftDelete := getFieldSoftDelete(&tf)
+		softDeleteByBool := false
+		if fieldSoftDelete {
+			switch vf.Interface().(type) {
+			case mysql.NullTime, sql.NullTime:
+				// OK
+			case bool, sql.NullBool:
+				softDeleteByBool = true
+			default:
+				return nil, fmt.Errorf("soft delete field '%s' should be a nullable time or a boolean", fieldName)
+			}
+		}
+
+		// version field should be an integer
+		fieldVersion := getFieldVersion(&tf)
+		if fieldVersion {
+			switch vf.Interface().(type) {
+			case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+				// OK
+			default:
+				return nil, fmt.Errorf("version field '%s' should be an integer", fieldName)
+			}
+			if fieldIncr {
+				return nil, fmt.Errorf("version field '%s' should not be auto-increment", fieldName)
+			}
+		}
+
 		// done
 		ret = append(ret, &Field{
-			Name:          fieldName,
-			Type:          fieldType,
-			Nullable:      fieldNull,
-			Default:       fieldDflt,
-			AutoIncrement: fieldIncr,
-			Comment:       fieldComt,
-			OnUpdate:      fieldOnUpdate,
+			Name:             fieldName,
+			Type:             fieldType,
+			Nullable:         fieldNull,
+			Default:          fieldDflt,
+			AutoIncrement:    fieldIncr,
+			Comment:          fieldComt,
+			OnUpdate:         fieldOnUpdate,
+			SoftDelete:       fieldSoftDelete,
+			Version:          fieldVersion,
+			softDeleteByBool: softDeleteByBool,
 		})
 	}
 	return
@@ -683,6 +744,26 @@ func getFieldAutoIncrement(tf *reflect.StructField, dft bool) bool {
 	}
 }
 
+func getFieldSoftDelete(tf *reflect.StructField) bool {
+	n := _readMysqlxTag(tf, "softdelete")
+	switch n {
+	case "true", "1":
+		return true
+	default:
+		return false
+	}
+}
+
+func getFieldVersion(tf *reflect.StructField) bool {
+	n := _readMysqlxTag(tf, "version")
+	switch n {
+	case "true", "1":
+		return true
+	default:
+		return false
+	}
+}
+
 func getFieldOnUpdate(tf *reflect.StructField) string {
 	n := _readMysqlxTag(tf, "onupdate")
 	return n
@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -11,10 +12,15 @@ import (
 
 // Delete executes SQL DELETE statement with given conditions
 func (d *xdb) Delete(prototype interface{}, args ...interface{}) (sql.Result, error) {
-	return d.delete(d.db, prototype, args...)
+	return d.delete(context.Background(), d.db, prototype, args...)
 }
 
-func (d *xdb) delete(obj sqlObj, prototype interface{}, args ...interface{}) (sql.Result, error) {
+// DeleteContext is the same as Delete, with a context.
+func (d *xdb) DeleteContext(ctx context.Context, prototype interface{}, args ...interface{}) (sql.Result, error) {
+	return d.delete(ctx, d.db, prototype, args...)
+}
+
+func (d *xdb) delete(ctx context.Context, obj sqlObj, prototype interface{}, args ...interface{}) (sql.Result, error) {
 	// Should be Xxx or *Xxx
 	ty := reflect.TypeOf(prototype)
 	va := reflect.ValueOf(prototype)
@@ -32,6 +38,12 @@ func (d *xdb) delete(obj sqlObj, prototype interface{}, args ...interface{}) (sq
 	if err != nil {
 		return nil, err
 	}
+	if err = checkHintsInModification("DELETE", parsedArgs); err != nil {
+		return nil, err
+	}
+	if len(parsedArgs.IndexHints) > 0 {
+		return nil, fmt.Errorf("index hints are not supported in single-table DELETE, please use optimizer hints instead")
+	}
 
 	// pack DELETE statements
 	var limitStr string
@@ -44,30 +56,45 @@ func (d *xdb) delete(obj sqlObj, prototype interface{}, args ...interface{}) (sq
 		orderStr = "ORDER BY " + strings.Join(parsedArgs.OrderList, ", ")
 	}
 
+	// soft delete never marks deleted records again, even with Unscoped
+	softDelete := parsedArgs.softDelete != nil && !parsedArgs.hardDelete
+	if softDelete && parsedArgs.unscoped {
+		parsedArgs.CondList = append(parsedArgs.CondList, notDeletedCond(parsedArgs.softDelete, parsedArgs.softDelete.Name))
+	}
+
 	var condStr string
 	if len(parsedArgs.CondList) > 0 {
 		condStr = "WHERE " + strings.Join(parsedArgs.CondList, " AND ")
 	}
 
-	// DELETE
-	query := fmt.Sprintf(
-		"DELETE FROM `%s` %s %s %s",
-		parsedArgs.Opt.TableName, condStr, orderStr, limitStr,
-	)
+	// DELETE, or UPDATE for soft delete
+	var query string
+	if softDelete {
+		query, err = packSoftDelete(parsedArgs.softDelete, parsedArgs, condStr, orderStr, limitStr)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		query = joinClauses(
+			"DELETE", packOptimizerHints(parsedArgs.OptimizerHints), "FROM", "`"+parsedArgs.Opt.TableName+"`",
+			condStr, orderStr, limitStr,
+		)
+	}
+	queryArgs := append(parsedArgs.CondArgs, parsedArgs.OrderArgs...)
 	// log.Println(query)
 	if parsedArgs.Opt.DoNotExec {
-		return nil, newError(doNotExec, query)
+		return nil, newErrorWithArgs(doNotExec, query, queryArgs)
 	}
 
 	// check auto create table
-	err = d.checkAutoCreateTable(prototype, parsedArgs.Opt)
+	err = d.checkAutoCreateTable(ctx, prototype, parsedArgs.Opt)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := obj.Exec(query)
+	res, err := obj.ExecContext(ctx, query, queryArgs...)
 	if err != nil {
-		return res, newError(err.Error(), query)
+		return res, newErrorWithArgs(err.Error(), query, queryArgs)
 	}
 	return res, nil
 }
@@ -0,0 +1,136 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// deleteByPKChunkSize is the maximum number of values in one IN list of DeleteByPK
+const deleteByPKChunkSize = 1000
+
+// requireCondType makes statements fail if no conditions are packed, which prevents deleting the whole table
+type requireCondType struct{}
+
+// DeleteByPK deletes records by values of the primary key, which should be a single field. Values could be given
+// one by one, or in slices. Large value lists are split into multiple DELETE statements, please invoke it in a
+// transaction if atomicity is required. Options arguments are also acceptable.
+func (d *xdb) DeleteByPK(prototype interface{}, ids ...interface{}) (sql.Result, error) {
+	return d.deleteByPK(context.Background(), d.db, prototype, ids...)
+}
+
+// DeleteByPKContext is the same as DeleteByPK, with a context.
+func (d *xdb) DeleteByPKContext(ctx context.Context, prototype interface{}, ids ...interface{}) (sql.Result, error) {
+	return d.deleteByPK(ctx, d.db, prototype, ids...)
+}
+
+func (d *xdb) deleteByPK(
+	ctx context.Context, obj sqlObj, prototype interface{}, ids ...interface{},
+) (sql.Result, error) {
+	prototype, err := getStructPrototype(prototype)
+	if err != nil {
+		return nil, err
+	}
+
+	// flatten values
+	var opt Options
+	values := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		switch v := id.(type) {
+		case Options:
+			opt = v
+			continue
+		case *Options:
+			opt = *v
+			continue
+		case []byte:
+			values = append(values, string(v))
+			continue
+		}
+		va := reflect.ValueOf(id)
+		if va.Kind() == reflect.Slice || va.Kind() == reflect.Array {
+			for i := 0; i < va.Len(); i++ {
+				values = append(values, va.Index(i).Interface())
+			}
+		} else {
+			values = append(values, id)
+		}
+	}
+	if 0 == len(values) {
+		return nil, fmt.Errorf("no primary key values given")
+	}
+
+	keys, err := d.primaryKeyFields(prototype, mergeOptions(prototype, opt))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != 1 {
+		return nil, fmt.Errorf("DeleteByPK only supports single-field primary key, please use DeleteEntity instead")
+	}
+
+	res := &multiResult{}
+	for start := 0; start < len(values); start += deleteByPKChunkSize {
+		end := start + deleteByPKChunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+		r, err := d.delete(ctx, obj, prototype, Condition(keys[0], "in", values[start:end]), opt, requireCondType{})
+		if err != nil {
+			return nil, err
+		}
+		if err = res.add(r); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// DeleteEntity deletes the record of given structure by its primary key values. Options arguments are also
+// acceptable.
+func (d *xdb) DeleteEntity(v interface{}, opts ...Options) (sql.Result, error) {
+	return d.deleteEntity(context.Background(), d.db, v, opts...)
+}
+
+// DeleteEntityContext is the same as DeleteEntity, with a context.
+func (d *xdb) DeleteEntityContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return d.deleteEntity(ctx, d.db, v, opts...)
+}
+
+func (d *xdb) deleteEntity(ctx context.Context, obj sqlObj, v interface{}, opts ...Options) (sql.Result, error) {
+	prototype, err := getStructPrototype(v)
+	if err != nil {
+		return nil, err
+	}
+	conds, err := d.primaryKeyConditions(prototype, mergeOptions(prototype, opts...))
+	if err != nil {
+		return nil, err
+	}
+	args := append(conds, optionsToArgs(opts)...)
+	return d.delete(ctx, obj, prototype, append(args, requireCondType{})...)
+}
+
+// primaryKeyConditions returns conditions of primary key values in given structure
+func (d *xdb) primaryKeyConditions(prototype interface{}, opt Options) ([]interface{}, error) {
+	keys, err := d.primaryKeyFields(prototype, opt)
+	if err != nil {
+		return nil, err
+	}
+	values, err := readStructValues(prototype)
+	if err != nil {
+		return nil, err
+	}
+
+	conds := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		val, err := convStructValueForUpdate(values[k])
+		if err != nil {
+			return nil, fmt.Errorf("key field '%s': %w", k, err)
+		}
+		if nil == val {
+			return nil, fmt.Errorf("key field '%s' is NULL", k)
+		}
+		conds = append(conds, Condition(k, "=", val))
+	}
+	return conds, nil
+}
@@ -0,0 +1,97 @@
+package mysqlx
+
+import (
+	"testing"
+)
+
+func TestDeleteByPK(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+	d.MustCreateTable(&txTestRecord{})
+
+	// more records than one chunk
+	records := make([]txTestRecord, deleteByPKChunkSize+10)
+	for i := range records {
+		records[i].String = "delete by pk"
+	}
+	_, err = d.InsertMany(records)
+	if err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+
+	var list []txTestRecord
+	err = d.Select(&list, Condition("f_string", "=", "delete by pk"))
+	if err != nil {
+		t.Errorf("Select error: %v", err)
+		return
+	}
+	ids := make([]int64, 0, len(list))
+	for _, r := range list {
+		ids = append(ids, r.ID)
+	}
+
+	// delete one entity
+	res, err := d.DeleteEntity(&list[0])
+	if err != nil {
+		t.Errorf("DeleteEntity error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Errorf("one row should be affected, but got %d", n)
+		return
+	}
+
+	// delete the rest
+	res, err = d.DeleteByPK(txTestRecord{}, ids)
+	if err != nil {
+		t.Errorf("DeleteByPK error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != int64(len(ids)-1) {
+		t.Errorf("%d rows should be affected, but got %d", len(ids)-1, n)
+		return
+	}
+
+	cnt, err := d.Count(txTestRecord{}, Condition("f_string", "=", "delete by pk"))
+	if err != nil {
+		t.Errorf("Count error: %v", err)
+		return
+	}
+	if cnt != 0 {
+		t.Errorf("all records should be deleted, but %d left", cnt)
+		return
+	}
+}
+
+type namedID int64
+
+func TestDeleteByNamedPK(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	_, err = d.DeleteByPK(&txTestRecord{}, []namedID{1, 2}, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "DELETE FROM `t_mysqlx_tx_test` WHERE `f_id` IN (1, 2)" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	// conditions which could not be packed should never be dropped
+	_, err = d.DeleteByPK(&txTestRecord{}, []interface{}{struct{}{}}, Options{DoNotExec: true})
+	if err == nil {
+		t.Errorf("error expected for unsupported values")
+		return
+	}
+	if query = GetQueryFromError(err); query != "" {
+		t.Errorf("error expected, but got statement: %s", query)
+		return
+	}
+}
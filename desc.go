@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -58,6 +59,10 @@ WHERE TABLE_SCHEMA='%s' AND TABLE_NAME='%s' ORDER BY ORDINAL_POSITION`
 
 // ReadTableFields returns all fields in given table
 func (d *xdb) ReadTableFields(table string) (ret []*Field, err error) {
+	return d.readTableFields(context.Background(), table)
+}
+
+func (d *xdb) readTableFields(ctx context.Context, table string) (ret []*Field, err error) {
 	if nil == d.db {
 		return nil, fmt.Errorf("mysqlx not initialized")
 	}
@@ -68,7 +73,7 @@ func (d *xdb) ReadTableFields(table string) (ret []*Field, err error) {
 	database := d.param.DBName
 	if "" == database {
 		var err error
-		database, err = d.CurrentDatabase()
+		database, err = d.currentDatabase(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -77,7 +82,7 @@ func (d *xdb) ReadTableFields(table string) (ret []*Field, err error) {
 	// query := fmt.Sprintf(_ReadTableFields, table)
 	query := fmt.Sprintf(_ReadTableFields, database, table)
 	var fields []*_Field
-	err = d.db.Select(&fields, query)
+	err = d.db.SelectContext(ctx, &fields, query)
 	if err != nil {
 		return nil, newError(err.Error(), query)
 	}
@@ -128,8 +133,12 @@ type currDB struct {
 
 // CurrentDatabase gets current operating database
 func (d *xdb) CurrentDatabase() (string, error) {
+	return d.currentDatabase(context.Background())
+}
+
+func (d *xdb) currentDatabase(ctx context.Context) (string, error) {
 	var res []currDB
-	err := d.db.Select(&res, "select database()")
+	err := d.db.SelectContext(ctx, &res, "select database()")
 	if err != nil {
 		return "", err
 	}
@@ -163,6 +172,10 @@ const _ReadTableIndexes = "SELECT TABLE_NAME, NON_UNIQUE, INDEX_NAME, SEQ_IN_IND
 
 // ReadTableIndexes returns all indexes and uniques of given table name
 func (d *xdb) ReadTableIndexes(table string) (map[string]*Index, map[string]*Unique, error) {
+	return d.readTableIndexes(context.Background(), table)
+}
+
+func (d *xdb) readTableIndexes(ctx context.Context, table string) (map[string]*Index, map[string]*Unique, error) {
 	if nil == d.db {
 		return nil, nil, fmt.Errorf("mysqlx not initialized")
 	}
@@ -173,7 +186,7 @@ func (d *xdb) ReadTableIndexes(table string) (map[string]*Index, map[string]*Uni
 	database := d.param.DBName
 	if "" == database {
 		var err error
-		database, err = d.CurrentDatabase()
+		database, err = d.currentDatabase(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -183,7 +196,7 @@ func (d *xdb) ReadTableIndexes(table string) (map[string]*Index, map[string]*Uni
 	var indexes []*_Index
 
 	query := fmt.Sprintf(_ReadTableIndexes, database, table)
-	err = d.db.Select(&indexes, query)
+	err = d.db.SelectContext(ctx, &indexes, query)
 	if err != nil {
 		return nil, nil, newError(err.Error(), query)
 	}
@@ -226,7 +239,8 @@ func (d *xdb) ReadTableIndexes(table string) (map[string]*Index, map[string]*Uni
 	return indexMap, uniqueMap, nil
 }
 
-// ReadStructFields returns all valid SQL fields by given structure and will buffer it
+// ReadStructFields returns all valid SQL fields by given structure and will buffer it. Buffered fields are shared
+// by all callers, please do not modify them.
 func (d *xdb) ReadStructFields(s interface{}) (ret []*Field, err error) {
 	// read from buffer
 	intfName := reflect.TypeOf(s)
@@ -237,7 +251,7 @@ func (d *xdb) ReadStructFields(s interface{}) (ret []*Field, err error) {
 
 	// read now
 	ret, err = ReadStructFields(s)
-	if err != nil {
+	if err == nil {
 		d.bufferedFields.Store(intfName, ret)
 	}
 	return
@@ -270,3 +284,42 @@ func ReadStructFields(s interface{}) (ret []*Field, err error) {
 
 	return readStructFields(t, v)
 }
+
+// readStructValues returns values of all fields with db tags in a structure or pointer to structure, including
+// those in embedded structures. Keys of the returned map are field names in db tags.
+func readStructValues(s interface{}) (map[string]interface{}, error) {
+	v := reflect.ValueOf(s)
+	if reflect.Ptr == v.Kind() {
+		v = v.Elem()
+	}
+	if reflect.Struct != v.Kind() {
+		return nil, fmt.Errorf("invalid type: %v", v.Kind())
+	}
+
+	ret := map[string]interface{}{}
+	readStructValuesToMap(v, ret)
+	return ret, nil
+}
+
+func readStructValuesToMap(v reflect.Value, m map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tf := t.Field(i)
+		vf := v.Field(i)
+		if false == vf.CanInterface() {
+			continue
+		}
+
+		fieldName := getFieldName(&tf)
+		if fieldName == "-" {
+			continue
+		}
+		if fieldName == "" {
+			if tf.Type.Kind() == reflect.Struct {
+				readStructValuesToMap(vf, m)
+			}
+			continue
+		}
+		m[fieldName] = vf.Interface()
+	}
+}
@@ -0,0 +1,41 @@
+package mysqlx
+
+import (
+	"testing"
+)
+
+func TestReadStructFieldsBuffer(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	// failures should never be buffered
+	for i := 0; i < 2; i++ {
+		if _, err := d.ReadStructFields(1); err == nil {
+			t.Errorf("ReadStructFields with int should fail in round %d", i)
+			return
+		}
+	}
+
+	// successful results should be buffered and reused
+	first, err := d.ReadStructFields(txTestRecord{})
+	if err != nil {
+		t.Errorf("ReadStructFields error: %v", err)
+		return
+	}
+	if len(first) == 0 {
+		t.Errorf("no fields read")
+		return
+	}
+	second, err := d.ReadStructFields(txTestRecord{})
+	if err != nil {
+		t.Errorf("ReadStructFields error: %v", err)
+		return
+	}
+	if len(second) != len(first) || second[0] != first[0] {
+		t.Errorf("fields are not read from buffer")
+		return
+	}
+}
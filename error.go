@@ -1,19 +1,33 @@
 package mysqlx
 
 import (
+	"errors"
 	"fmt"
 )
 
 const doNotExec = "exec blocked in options"
 
+// ErrStaleVersion is returned by Update, Save and UpdateStruct if the structure has a version field and no rows
+// are affected, which means that the record has been modified by others, or does not exist.
+var ErrStaleVersion = errors.New("stale version")
+
+// ErrSoftDeleted is returned by GetOrInsert if the record to insert conflicts with a soft deleted record on a
+// unique key.
+var ErrSoftDeleted = errors.New("conflicting record is soft deleted")
+
 type sqlIntf interface {
 	Query() string
 }
 
+type argsIntf interface {
+	Args() []interface{}
+}
+
 // Error is the error type identified by mysqlx
 type Error struct {
-	err string
-	sql string
+	err  string
+	sql  string
+	args []interface{}
 }
 
 // Error returns error message of the error
@@ -34,6 +48,12 @@ func (e *Error) Query() string {
 	return e.sql
 }
 
+// Args returns arguments of placeholders in query statements stored in Error object. It is always empty unless
+// Param.UsePlaceholder is enabled.
+func (e *Error) Args() []interface{} {
+	return e.args
+}
+
 // Errorf generates an formatted error object
 func Errorf(format string, args ...interface{}) *Error {
 	err := fmt.Sprintf(format, args...)
@@ -51,6 +71,23 @@ func newError(err, sql string) *Error {
 	}
 }
 
+// newErrorWithArgs returns an error with sql statements and its placeholder arguments
+func newErrorWithArgs(err, sql string, args []interface{}) *Error {
+	return &Error{
+		err:  err,
+		sql:  sql,
+		args: args,
+	}
+}
+
+// GetArgsFromError fetch placeholder arguments of SQL query statements in returned error type by mysqlx.
+func GetArgsFromError(e error) []interface{} {
+	if a, ok := interface{}(e).(argsIntf); ok {
+		return a.Args()
+	}
+	return nil
+}
+
 // GetQueryFromError fetch SQL query statements in returned error type by mysqlx.
 func GetQueryFromError(e error) string {
 	if query, ok := interface{}(e).(sqlIntf); ok {
@@ -14,26 +14,25 @@ var (
 		// "\"", "\\\"",
 		// "'", "\\'",
 	)
-	likeStringReplacer = strings.NewReplacer(
-		// "'", "''",
-		"\n", "\\n",
-		"\t", "\\t",
-		"\r", "\\r",
-		"\b", "\\b",
+	likePatternReplacer = strings.NewReplacer(
 		"\\", "\\\\",
-		"\032", "\\Z",
 		"%", "\\%",
-		// "\"", "\\\"",
-		// "'", "\\'",
 	)
 )
 
+// quoteField back-quotes a field name. Qualified names like "t_user.id" will be quoted as "`t_user`.`id`".
+func quoteField(name string) string {
+	parts := strings.Split(name, ".")
+	return "`" + strings.Join(parts, "`.`") + "`"
+}
+
 func escapeValueString(s string) string {
 	// return s
 	return stringReplacer.Replace(s)
 }
 
-func escapeLikeValueString(s string) string {
-	// return s
-	return likeStringReplacer.Replace(s)
+// escapeLikePattern escapes wildcard '%' and escape character '\' in a LIKE pattern. The result is not escaped
+// as a string value yet.
+func escapeLikePattern(s string) string {
+	return likePatternReplacer.Replace(s)
 }
@@ -117,3 +117,88 @@ func TestSpecialCharacters(t *testing.T) {
 
 	return
 }
+
+func TestSpecialCharactersWithPlaceholder(t *testing.T) {
+	db, err := Open(Param{
+		User:           "travis",
+		DBName:         "db_test",
+		UsePlaceholder: true,
+	})
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	db.MustCreateTable(String{})
+
+	s := String{
+		S:       "initial",
+		Created: time.Now().Unix(),
+	}
+	_, err = db.Insert(s, Options{DoNotExec: true})
+	t.Logf("statement: %s, args: %v", GetQueryFromError(err), GetArgsFromError(err))
+
+	res, err := db.Insert(s)
+	if err != nil {
+		t.Errorf("Insert error: %v", err)
+		return
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		t.Errorf("none inserted %v", err)
+		return
+	}
+
+	speStrings := []string{
+		`<%_％＿'"` + "`" + `%\r\n\t\b	>` + "\r\n\\'\032",
+		"'",
+		"\\%",
+	}
+
+	for _, s := range speStrings {
+		err := testSpecialString(t, db, int32(id), s)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestLikeCondition(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	c := Like("string", []string{"", "100%", "it's", ""})
+	if v, ok := c.Value.(string); !ok || v != `%100\%%it's%` {
+		t.Errorf("unexpected LIKE value: %v", c.Value)
+		return
+	}
+
+	var res []String
+	err = db.Select(&res, c, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "SELECT `id`, `string`, `create_timestamp` FROM `t_string` WHERE `string` LIKE '%100\\\\%%it\\'s%'" {
+		t.Errorf("unexpected statement")
+		return
+	}
+}
+
+func TestInConditionEscaping(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	var res []String
+	err = db.Select(&res, Condition("string", "in", []string{"it's", `a\b`}), Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "SELECT `id`, `string`, `create_timestamp` FROM `t_string` WHERE `string` IN ('it\\'s', 'a\\\\b')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+}
@@ -0,0 +1,127 @@
+package mysqlx
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnRef references a field of the table. It could be used as a condition value to compare two fields, such
+// as Condition("updated_at", ">", Col("created_at")), or as an argument of Expr.
+type ColumnRef string
+
+// Col returns a ColumnRef with given field name
+func Col(field string) ColumnRef {
+	return ColumnRef(field)
+}
+
+func (c ColumnRef) pack(fieldMap map[string]*Field) (string, error) {
+	if _, exist := fieldMap[string(c)]; !exist {
+		return "", fmt.Errorf("field '%s' not recognized", string(c))
+	}
+	return quoteField(string(c)), nil
+}
+
+// Expression is a raw SQL expression with '?' placeholders. Every '?' in SQL will be replaced by the escaped
+// value of the corresponding argument, or kept as placeholder if Param.UsePlaceholder is enabled. Arguments could
+// be ColumnRef values, which will be replaced by quoted field names. Please note that the SQL part is NOT escaped.
+//
+// Expressions could be used as condition values, such as Condition("balance", ">=", Expr("? + ?", Col("frozen"),
+// 100)), or in ORDER BY statements by OrderByExpr.
+type Expression struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Expr returns an Expression with given SQL and arguments
+func Expr(sql string, args ...interface{}) *Expression {
+	return &Expression{SQL: sql, Args: args}
+}
+
+func (e *Expression) pack(fieldMap map[string]*Field, b *binder) (string, error) {
+	if nil == e {
+		return "", fmt.Errorf("nil expression")
+	}
+	if n := strings.Count(e.SQL, "?"); n != len(e.Args) {
+		return "", fmt.Errorf("%d argument(s) expected in expression '%s', but got %d", n, e.SQL, len(e.Args))
+	}
+
+	parts := strings.Split(e.SQL, "?")
+	sb := strings.Builder{}
+	sb.WriteString(parts[0])
+	for i, arg := range e.Args {
+		s, err := packExpressionArg(arg, fieldMap, b)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s)
+		sb.WriteString(parts[i+1])
+	}
+	return sb.String(), nil
+}
+
+func packExpressionArg(arg interface{}, fieldMap map[string]*Field, b *binder) (string, error) {
+	switch v := arg.(type) {
+	default:
+		return packKindArg(arg, b)
+	case ColumnRef:
+		return v.pack(fieldMap)
+	case int, int64, int32, int16, int8:
+		n := reflect.ValueOf(v).Int()
+		return b.bind(strconv.FormatInt(n, 10), n), nil
+	case uint, uint64, uint32, uint16, uint8:
+		n := reflect.ValueOf(v).Uint()
+		return b.bind(strconv.FormatUint(n, 10), n), nil
+	case bool:
+		return b.bind(convBoolToString(v), v), nil
+	case float32, float64:
+		f := reflect.ValueOf(v).Float()
+		return b.bind(fmt.Sprintf("%f", f), f), nil
+	case string:
+		return b.bind(addQuoteToString(escapeValueString(v), "'"), v), nil
+	case time.Time:
+		return b.bindTime(v.Format("'2006-01-02 15:04:05.999999'")), nil
+	case nil:
+		return "NULL", nil
+	}
+}
+
+// packKindArg packs values of named types by their underlying kinds, such as ID defined as int64
+func packKindArg(arg interface{}, b *binder) (string, error) {
+	va := reflect.ValueOf(arg)
+	switch va.Kind() {
+	default:
+		return "", fmt.Errorf("unsupported expression argument type %T", arg)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := va.Int()
+		return b.bind(strconv.FormatInt(n, 10), n), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := va.Uint()
+		return b.bind(strconv.FormatUint(n, 10), n), nil
+	case reflect.String:
+		s := va.String()
+		return b.bind(addQuoteToString(escapeValueString(s), "'"), s), nil
+	}
+}
+
+// OrderExpr is for MySQL ORDER BY statement with an expression
+type OrderExpr struct {
+	Expr *Expression
+	Seq  string
+}
+
+// OrderByExpr returns an OrderExpr, such as OrderByExpr(Expr("FIELD(`status`, ?, ?)", 2, 1), "ASC")
+func OrderByExpr(expr *Expression, seq string) *OrderExpr {
+	return &OrderExpr{Expr: expr, Seq: seq}
+}
+
+func (o *OrderExpr) pack(fieldMap map[string]*Field, b *binder) string {
+	s, err := o.Expr.pack(fieldMap, b)
+	if err != nil {
+		b.fail(err)
+		return ""
+	}
+	return fmt.Sprintf("%s %s", s, o.Seq)
+}
@@ -0,0 +1,78 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumnAndExpression(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	err = db.CreateTable(User{})
+	if err != nil {
+		t.Errorf("CreateTable error: %v", err)
+		return
+	}
+
+	var res []*User
+	args := []interface{}{
+		Condition("update_timestamp", ">", Col("birth_date")),
+		Condition("status_masks", ">=", Expr("? + ?", Col("id"), 100)),
+		OrderByExpr(Expr("FIELD(`gender`, ?, ?)", "Female", "Male"), "ASC"),
+	}
+	err = db.Select(&res, append(args, Options{DoNotExec: true})...)
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.Contains(query, "WHERE `update_timestamp` > `birth_date` AND `status_masks` >= (`id` + 100)") ||
+		!strings.HasSuffix(query, "ORDER BY FIELD(`gender`, 'Female', 'Male') ASC") {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	err = db.Select(&res, args...)
+	if err != nil {
+		t.Errorf("Select error: %v", err)
+		return
+	}
+
+	// invalid field references and expressions should be reported
+	err = db.Select(&res, Condition("update_timestamp", ">", Col("not_exist")))
+	if err == nil {
+		t.Errorf("error expected for unknown field")
+		return
+	}
+	err = db.Select(&res, Condition("id", ">", Expr("? + ?", 1)))
+	if err == nil {
+		t.Errorf("error expected for mismatched arguments")
+		return
+	}
+}
+
+func TestExpressionWithNamedTypes(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	type gender string
+	type mask uint64
+
+	var res []*User
+	err = db.Select(
+		&res, Condition("status_masks", ">=", Expr("? + ?", Col("id"), mask(100))),
+		OrderByExpr(Expr("FIELD(`gender`, ?, ?)", gender("Female"), gender("Male")), "ASC"),
+		Options{DoNotExec: true},
+	)
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.Contains(query, "WHERE `status_masks` >= (`id` + 100)") ||
+		!strings.HasSuffix(query, "ORDER BY FIELD(`gender`, 'Female', 'Male') ASC") {
+		t.Errorf("unexpected statement")
+		return
+	}
+}
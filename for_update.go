@@ -1,9 +1,92 @@
 package mysqlx
 
-// ForUpdateType is returned by ForUpdate()
-type ForUpdateType struct{}
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ForUpdateType is returned by ForUpdate(), ForShare() and LockInShareMode(). It identifies a locking read
+// statement section, which is only allowed in transactions.
+type ForUpdateType struct {
+	share       bool
+	inShareMode bool
+	wait        string
+	of          []string
+}
 
 // ForUpdate is used in transaction to generate a FOR UPDATE statement section
 func ForUpdate() *ForUpdateType {
 	return &ForUpdateType{}
 }
+
+// ForShare is used in transaction to generate a FOR SHARE statement section. It requires MySQL 8.0 or above.
+func ForShare() *ForUpdateType {
+	return &ForUpdateType{share: true}
+}
+
+// LockInShareMode is used in transaction to generate a LOCK IN SHARE MODE statement section. It is the same as
+// ForShare, and is supported by MySQL versions before 8.0. NoWait, SkipLocked and Of are not allowed with it.
+func LockInShareMode() *ForUpdateType {
+	return &ForUpdateType{share: true, inShareMode: true}
+}
+
+// NoWait makes the locking read return an error immediately if a row lock could not be acquired.
+func (f *ForUpdateType) NoWait() *ForUpdateType {
+	f.wait = "NOWAIT"
+	return f
+}
+
+// SkipLocked makes the locking read skip rows which are locked by other transactions. It is useful when multiple
+// workers claim jobs from the same table.
+func (f *ForUpdateType) SkipLocked() *ForUpdateType {
+	f.wait = "SKIP LOCKED"
+	return f
+}
+
+// Of limits the locking read to given tables. Names should be table names or aliases used in the statement.
+func (f *ForUpdateType) Of(tables ...string) *ForUpdateType {
+	f.of = append(f.of, tables...)
+	return f
+}
+
+func (f *ForUpdateType) pack() (string, error) {
+	if f.inShareMode {
+		if f.wait != "" || len(f.of) > 0 {
+			return "", fmt.Errorf("NOWAIT, SKIP LOCKED and OF are not supported in LOCK IN SHARE MODE")
+		}
+		return "LOCK IN SHARE MODE", nil
+	}
+
+	parts := []string{"FOR UPDATE"}
+	if f.share {
+		parts[0] = "FOR SHARE"
+	}
+	if len(f.of) > 0 {
+		tables := make([]string, 0, len(f.of))
+		for _, t := range f.of {
+			if t == "" {
+				return "", fmt.Errorf("empty table name in OF")
+			}
+			tables = append(tables, "`"+t+"`")
+		}
+		parts = append(parts, "OF "+strings.Join(tables, ", "))
+	}
+	if f.wait != "" {
+		parts = append(parts, f.wait)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// checkLockingRead returns an error if a locking read is executed outside a transaction, as the lock would be
+// released immediately with auto-commit.
+func checkLockingRead(obj sqlObj, parsedArgs *_parsedArgs) error {
+	if parsedArgs.lock == "" {
+		return nil
+	}
+	if _, isTx := obj.(*sqlx.Tx); isTx {
+		return nil
+	}
+	return fmt.Errorf("'%s' is only allowed in transaction", parsedArgs.lock)
+}
@@ -0,0 +1,98 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// This file provides typed functions over CURD. T should be a table structure type. Both DB and Tx could be
+// passed as the db parameter. Field information is read from the same buffer as the CURD methods.
+
+// SelectT executes a SQL select statement and returns records in a slice of T. T could be either Xxx or *Xxx.
+func SelectT[T any](db CURD, args ...interface{}) ([]T, error) {
+	return SelectTContext[T](context.Background(), db, args...)
+}
+
+// SelectTContext is the same as SelectT, with a context.
+func SelectTContext[T any](ctx context.Context, db CURD, args ...interface{}) ([]T, error) {
+	if _, err := typedPrototype[T](); err != nil {
+		return nil, err
+	}
+	var res []T
+	if err := db.SelectContext(ctx, &res, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetT selects the first record matching given arguments. If no record matches, sql.ErrNoRows will be returned.
+func GetT[T any](db CURD, args ...interface{}) (*T, error) {
+	return GetTContext[T](context.Background(), db, args...)
+}
+
+// GetTContext is the same as GetT, with a context.
+func GetTContext[T any](ctx context.Context, db CURD, args ...interface{}) (*T, error) {
+	if err := checkStructType[T](); err != nil {
+		return nil, err
+	}
+	res := new(T)
+	if err := db.GetContext(ctx, res, args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// InsertT inserts a given record, the same as Insert.
+func InsertT[T any](db CURD, v *T, opts ...Options) (sql.Result, error) {
+	return InsertTContext(context.Background(), db, v, opts...)
+}
+
+// InsertTContext is the same as InsertT, with a context.
+func InsertTContext[T any](ctx context.Context, db CURD, v *T, opts ...Options) (sql.Result, error) {
+	if err := checkStructType[T](); err != nil {
+		return nil, err
+	}
+	if nil == v {
+		return nil, fmt.Errorf("nil record")
+	}
+	return db.InsertContext(ctx, v, opts...)
+}
+
+// UpdateT executes UPDATE statement in table of T with given fields and conditions, the same as Update.
+func UpdateT[T any](db CURD, fields map[string]interface{}, args ...interface{}) (sql.Result, error) {
+	return UpdateTContext[T](context.Background(), db, fields, args...)
+}
+
+// UpdateTContext is the same as UpdateT, with a context.
+func UpdateTContext[T any](
+	ctx context.Context, db CURD, fields map[string]interface{}, args ...interface{},
+) (sql.Result, error) {
+	prototype, err := typedPrototype[T]()
+	if err != nil {
+		return nil, err
+	}
+	return db.UpdateContext(ctx, prototype, fields, args...)
+}
+
+// checkStructType checks if T is a structure type
+func checkStructType[T any]() error {
+	ty := reflect.TypeOf((*T)(nil)).Elem()
+	if reflect.Struct != ty.Kind() {
+		return fmt.Errorf("type %v is not a structure", ty)
+	}
+	return nil
+}
+
+// typedPrototype returns a zero Xxx value for T, which could be either Xxx or *Xxx
+func typedPrototype[T any]() (interface{}, error) {
+	ty := reflect.TypeOf((*T)(nil)).Elem()
+	if reflect.Ptr == ty.Kind() {
+		ty = ty.Elem()
+	}
+	if reflect.Struct != ty.Kind() {
+		return nil, fmt.Errorf("type %v is not a structure or a pointer to structure", ty)
+	}
+	return reflect.New(ty).Elem().Interface(), nil
+}
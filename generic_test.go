@@ -0,0 +1,62 @@
+package mysqlx
+
+import (
+	"testing"
+)
+
+func TestGenerics(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	r := txTestRecord{String: "generics"}
+	d.MustCreateTable(&r)
+
+	res, err := InsertT(d, &r)
+	if err != nil {
+		t.Errorf("InsertT error: %v", err)
+		return
+	}
+	id, _ := res.LastInsertId()
+
+	list, err := SelectT[txTestRecord](d, Condition("f_id", "=", id))
+	if err != nil {
+		t.Errorf("SelectT error: %v", err)
+		return
+	}
+	if len(list) != 1 || list[0].String != r.String {
+		t.Errorf("unexpected records: %+v", list)
+		return
+	}
+
+	_, err = UpdateT[*txTestRecord](d, map[string]interface{}{"f_string": "generics updated"}, Condition("f_id", "=", id))
+	if err != nil {
+		t.Errorf("UpdateT error: %v", err)
+		return
+	}
+
+	tx, err := d.Begin()
+	if err != nil {
+		t.Errorf("Begin error: %v", err)
+		return
+	}
+	got, err := GetT[txTestRecord](tx, Condition("f_id", "=", id), ForUpdate())
+	tx.Rollback()
+	if err != nil {
+		t.Errorf("GetT error: %v", err)
+		return
+	}
+	if got.ID != id || got.String != "generics updated" {
+		t.Errorf("unexpected record: %+v", got)
+		return
+	}
+
+	// non-structure type
+	_, err = SelectT[int](d)
+	if err == nil {
+		t.Errorf("error expected for non-structure type")
+		return
+	}
+}
@@ -0,0 +1,45 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// Get selects the first record matching given arguments into dst, which should be a pointer to a structure.
+// "LIMIT 1" is always added. If no record matches, sql.ErrNoRows will be returned.
+func (d *xdb) Get(dst interface{}, args ...interface{}) error {
+	return d.get(context.Background(), d.db, dst, args...)
+}
+
+// GetContext is the same as Get, with a context.
+func (d *xdb) GetContext(ctx context.Context, dst interface{}, args ...interface{}) error {
+	return d.get(ctx, d.db, dst, args...)
+}
+
+func (d *xdb) get(ctx context.Context, obj sqlObj, dst interface{}, args ...interface{}) error {
+	// Should be *Xxx
+	if nil == dst {
+		return fmt.Errorf("nil destination")
+	}
+	va := reflect.ValueOf(dst)
+	if reflect.Ptr != va.Kind() || va.IsNil() || reflect.Struct != va.Elem().Kind() {
+		return fmt.Errorf("parameter type invalid (%v), should be a pointer to structure", va.Type())
+	}
+
+	// select into []*Xxx
+	list := reflect.New(reflect.SliceOf(va.Type()))
+	args = append(append(make([]interface{}, 0, len(args)+1), args...), Limit(1))
+	err := d.selectFunc(ctx, obj, list.Interface(), args...)
+	if err != nil {
+		return err
+	}
+
+	list = list.Elem()
+	if 0 == list.Len() {
+		return sql.ErrNoRows
+	}
+	va.Elem().Set(list.Index(0).Elem())
+	return nil
+}
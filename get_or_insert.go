@@ -0,0 +1,105 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GetOrInsert selects the first record matching given conditions into dst, which should be a pointer to the same
+// structure type as insert. If no record matches, insert is inserted by 'INSERT ... ON DUPLICATE KEY UPDATE'
+// statement which changes nothing on duplicated keys, and the record is selected again. inserted tells whether the
+// record is inserted by this invocation. Unlike 'INSERT IGNORE', other errors such as truncation are still
+// reported.
+//
+// The table should have a unique key (or the primary key) covering the conditions. With such a key, concurrent
+// invocations get the same record without duplicate-key errors. An auto-increment field is not required. If the
+// conflicting record is soft deleted, ErrSoftDeleted is returned with the soft deleted record in dst.
+//
+// If "clientFoundRows" is enabled in Param.Params, MySQL could not tell inserted records from existing ones, and
+// inserted is also true if the record is inserted by others between selecting and inserting.
+func (d *xdb) GetOrInsert(dst, insert interface{}, conds ...interface{}) (inserted bool, err error) {
+	return d.getOrInsert(context.Background(), d.db, dst, insert, conds...)
+}
+
+// GetOrInsertContext is the same as GetOrInsert, with a context.
+func (d *xdb) GetOrInsertContext(
+	ctx context.Context, dst, insert interface{}, conds ...interface{},
+) (inserted bool, err error) {
+	return d.getOrInsert(ctx, d.db, dst, insert, conds...)
+}
+
+func (d *xdb) getOrInsert(
+	ctx context.Context, obj sqlObj, dst, insert interface{}, conds ...interface{},
+) (inserted bool, err error) {
+	prototype, err := getStructPrototype(insert)
+	if err != nil {
+		return false, err
+	}
+	if reflect.TypeOf(dst) != reflect.PtrTo(reflect.TypeOf(prototype)) {
+		return false, fmt.Errorf("type %T mismatches %T", dst, insert)
+	}
+
+	// check conditions. Soft delete condition is excluded.
+	parsedArgs, err := d.handleArgs(prototype, append(append([]interface{}{}, conds...), Unscoped()))
+	if err != nil {
+		return false, err
+	}
+	if 0 == len(parsedArgs.CondList) {
+		return false, fmt.Errorf("select conditions not given")
+	}
+
+	// select first
+	err = d.get(ctx, obj, dst, conds...)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	// insert and select again. The first primary key field, or the first field if no primary key is declared, is
+	// "updated" to itself on duplicated keys, which makes 1 affected row for inserted and 0 for existing.
+	fields, err := d.ReadStructFields(prototype)
+	if err != nil {
+		return false, err
+	}
+	field := fields[0].Name
+	if keys, err := d.primaryKeyFields(prototype, parsedArgs.Opt); err == nil {
+		field = keys[0]
+	}
+	res, err := d.insertOnDuplicateKeyUpdate(
+		ctx, obj, insert, map[string]interface{}{field: Expr("?", Col(field))}, parsedArgs.Opt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	// In a transaction, the record inserted by others could not be read by consistent reads, therefore a locking
+	// read is used.
+	args := conds
+	if _, isTx := obj.(*sqlx.Tx); isTx {
+		args = append(append([]interface{}{}, conds...), LockInShareMode())
+	}
+	err = d.get(ctx, obj, dst, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		if d.softDeleteField(prototype) != nil {
+			if err = d.get(ctx, obj, dst, append(args, Unscoped())...); err == nil {
+				return false, ErrSoftDeleted
+			}
+		}
+		return false, fmt.Errorf(
+			"no record matches conditions after inserting, the record to insert may not match conditions, " +
+				"or conflict with another record on a unique key",
+		)
+	}
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,145 @@
+package mysqlx
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type kvRecord struct {
+	Key   string `db:"f_key"         mysqlx:"type:varchar(64)"`
+	Value string `db:"f_value"       mysqlx:"type:varchar(128)"`
+}
+
+func (kvRecord) Options() Options {
+	return Options{
+		TableName:  "t_mysqlx_kv_test",
+		PrimaryKey: []string{"f_key"},
+	}
+}
+
+func TestGetOrInsert(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+	d.Sqlx().Exec("DROP TABLE `t_mysqlx_kv_test`")
+	d.MustCreateTable(kvRecord{})
+
+	var got kvRecord
+	inserted, err := d.GetOrInsert(&got, kvRecord{Key: "a", Value: "first"}, Condition("f_key", "=", "a"))
+	if err != nil {
+		t.Errorf("GetOrInsert error: %v", err)
+		return
+	}
+	if !inserted || got.Value != "first" {
+		t.Errorf("record should be inserted, got %v, %+v", inserted, got)
+		return
+	}
+
+	inserted, err = d.GetOrInsert(&got, kvRecord{Key: "a", Value: "second"}, Condition("f_key", "=", "a"))
+	if err != nil {
+		t.Errorf("GetOrInsert error: %v", err)
+		return
+	}
+	if inserted || got.Value != "first" {
+		t.Errorf("record should not be inserted, got %v, %+v", inserted, got)
+		return
+	}
+
+	// concurrent invocations
+	const n = 10
+	wg := sync.WaitGroup{}
+	results := make([]kvRecord, n)
+	errs := make([]error, n)
+	insertedList := make([]bool, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			insertedList[i], errs[i] = d.GetOrInsert(
+				&results[i], &kvRecord{Key: "b", Value: "concurrent"}, Condition("f_key", "=", "b"),
+			)
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			t.Errorf("GetOrInsert error: %v", errs[i])
+			return
+		}
+		if results[i] != (kvRecord{Key: "b", Value: "concurrent"}) {
+			t.Errorf("unexpected record: %+v", results[i])
+			return
+		}
+		if insertedList[i] {
+			total++
+		}
+	}
+	if total != 1 {
+		t.Errorf("record should be inserted once, but got %d", total)
+		return
+	}
+
+	// mismatched types
+	_, err = d.GetOrInsert(&User{}, kvRecord{}, Condition("f_key", "=", "c"))
+	if err == nil {
+		t.Errorf("error expected for mismatched types")
+		return
+	}
+}
+
+type softKVRecord struct {
+	Key       string       `db:"f_key"         mysqlx:"type:varchar(64)"`
+	Value     string       `db:"f_value"       mysqlx:"type:varchar(16)"`
+	DeletedAt sql.NullTime `db:"f_deleted_at"  mysqlx:"type:datetime(3) softdelete:true"`
+}
+
+func (softKVRecord) Options() Options {
+	return Options{
+		TableName:  "t_mysqlx_soft_kv_test",
+		PrimaryKey: []string{"f_key"},
+	}
+}
+
+func TestGetOrInsertErrors(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+	d.Sqlx().Exec("DROP TABLE `t_mysqlx_soft_kv_test`")
+	d.MustCreateTable(softKVRecord{})
+
+	// invalid values should not be reported as existing records
+	var got softKVRecord
+	_, err = d.GetOrInsert(
+		&got, softKVRecord{Key: "long", Value: strings.Repeat("x", 32)}, Condition("f_key", "=", "long"),
+	)
+	if err == nil {
+		t.Errorf("error expected for too long value")
+		return
+	}
+	t.Logf("expected error: %v", err)
+
+	// conflicting with soft deleted record
+	inserted, err := d.GetOrInsert(&got, softKVRecord{Key: "deleted"}, Condition("f_key", "=", "deleted"))
+	if err != nil || !inserted {
+		t.Errorf("GetOrInsert error: %v, inserted: %v", err, inserted)
+		return
+	}
+	if _, err = d.Delete(&softKVRecord{}, Condition("f_key", "=", "deleted")); err != nil {
+		t.Errorf("Delete error: %v", err)
+		return
+	}
+	_, err = d.GetOrInsert(&got, softKVRecord{Key: "deleted"}, Condition("f_key", "=", "deleted"))
+	if !errors.Is(err, ErrSoftDeleted) {
+		t.Errorf("ErrSoftDeleted expected, but got %v", err)
+		return
+	}
+}
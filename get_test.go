@@ -0,0 +1,65 @@
+package mysqlx
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	r := txTestRecord{String: "get"}
+	d.MustCreateTable(&r)
+
+	res, err := d.Insert(&r)
+	if err != nil {
+		t.Errorf("Insert error: %v", err)
+		return
+	}
+	id, _ := res.LastInsertId()
+
+	var got txTestRecord
+	err = d.Get(&got, Condition("f_id", "=", id), Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.Contains(query, "LIMIT 1") {
+		t.Errorf("LIMIT 1 expected")
+		return
+	}
+
+	err = d.Get(&got, Condition("f_id", "=", id))
+	if err != nil {
+		t.Errorf("Get error: %v", err)
+		return
+	}
+	if got.ID != id || got.String != r.String {
+		t.Errorf("unexpected record: %+v", got)
+		return
+	}
+
+	err = d.Get(&got, Condition("f_id", "<", 0))
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("sql.ErrNoRows expected, but got %v", err)
+		return
+	}
+
+	// in transaction
+	tx, err := d.Begin()
+	if err != nil {
+		t.Errorf("Begin error: %v", err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.Get(&got, Condition("f_id", "=", id), ForUpdate())
+	if err != nil {
+		t.Errorf("tx.Get error: %v", err)
+		return
+	}
+}
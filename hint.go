@@ -0,0 +1,175 @@
+package mysqlx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IndexHint identifies an index hint following a table name, such as "FORCE INDEX (`i_name`)". Index hints are
+// supported in Select and Update, but not in Delete, as MySQL does not allow them in single-table DELETE
+// statements. Use optimizer hints like Hint("NO_INDEX(t_user i_name)") instead in Delete.
+type IndexHint struct {
+	// Type is the hint type, such as "FORCE", "USE" or "IGNORE"
+	Type string
+	// Indexes are index names. "PRIMARY" identifies the primary key.
+	Indexes []string
+	// Table is the table alias which the hint applies to in a JOIN statement. If empty, the hint applies to the
+	// table selected from.
+	Table string
+}
+
+// ForceIndex returns an IndexHint with FORCE INDEX type
+func ForceIndex(indexes ...string) *IndexHint {
+	return &IndexHint{Type: "FORCE", Indexes: indexes}
+}
+
+// UseIndex returns an IndexHint with USE INDEX type
+func UseIndex(indexes ...string) *IndexHint {
+	return &IndexHint{Type: "USE", Indexes: indexes}
+}
+
+// IgnoreIndex returns an IndexHint with IGNORE INDEX type
+func IgnoreIndex(indexes ...string) *IndexHint {
+	return &IndexHint{Type: "IGNORE", Indexes: indexes}
+}
+
+// ForTable specifies the table alias which the hint applies to in a JOIN statement
+func (h *IndexHint) ForTable(alias string) *IndexHint {
+	h.Table = alias
+	return h
+}
+
+func (h *IndexHint) pack() (string, error) {
+	typ := strings.ToUpper(h.Type)
+	switch typ {
+	case "FORCE", "USE", "IGNORE":
+		// OK
+	default:
+		return "", fmt.Errorf("unsupported index hint type '%s'", h.Type)
+	}
+	if 0 == len(h.Indexes) && typ != "USE" {
+		return "", fmt.Errorf("no index given in %s INDEX", typ)
+	}
+
+	indexes := make([]string, 0, len(h.Indexes))
+	for _, idx := range h.Indexes {
+		if idx == "" {
+			return "", fmt.Errorf("empty index name in %s INDEX", typ)
+		}
+		indexes = append(indexes, "`"+idx+"`")
+	}
+	return fmt.Sprintf("%s INDEX (%s)", typ, strings.Join(indexes, ", ")), nil
+}
+
+// packIndexHints packs index hints joined by spaces
+func packIndexHints(hints []*IndexHint) (string, error) {
+	parts := make([]string, 0, len(hints))
+	for _, h := range hints {
+		s, err := h.pack()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// OptimizerHintType identifies an optimizer hint, which will be placed in a "/*+ ... */" comment following the
+// SELECT, UPDATE or DELETE keyword.
+type OptimizerHintType struct {
+	hint       string
+	selectOnly bool
+}
+
+// Hint returns an optimizer hint with given raw content, such as "NO_INDEX(t_user i_name)" or
+// "SET_VAR(sort_buffer_size = 16M)". The content will NOT be escaped.
+func Hint(hint string) *OptimizerHintType {
+	return &OptimizerHintType{hint: hint}
+}
+
+// MaxExecutionTime returns a MAX_EXECUTION_TIME optimizer hint, which limits the execution time of a SELECT
+// statement. The duration is rounded down to milliseconds.
+func MaxExecutionTime(d time.Duration) *OptimizerHintType {
+	return &OptimizerHintType{
+		hint:       fmt.Sprintf("MAX_EXECUTION_TIME(%d)", d.Milliseconds()),
+		selectOnly: true,
+	}
+}
+
+func packOptimizerHints(hints []*OptimizerHintType) string {
+	if 0 == len(hints) {
+		return ""
+	}
+	parts := make([]string, 0, len(hints))
+	for _, h := range hints {
+		parts = append(parts, h.hint)
+	}
+	return "/*+ " + strings.Join(parts, " ") + " */"
+}
+
+// StraightJoinType is returned by StraightJoin()
+type StraightJoinType struct{}
+
+// StraightJoin makes the optimizer join tables in the order in which they are listed in a JOIN select.
+func StraightJoin() *StraightJoinType {
+	return &StraightJoinType{}
+}
+
+// checkHintsInModification returns an error if hints only allowed in SELECT are given to UPDATE or DELETE
+func checkHintsInModification(statement string, parsedArgs *_parsedArgs) error {
+	if parsedArgs.straightJoin {
+		return fmt.Errorf("STRAIGHT_JOIN is not supported in %s", statement)
+	}
+	for _, h := range parsedArgs.OptimizerHints {
+		if h.selectOnly {
+			return fmt.Errorf("optimizer hint '%s' is only supported in SELECT", h.hint)
+		}
+	}
+	return nil
+}
+
+// checkIndexHints checks if indexes in hints exist in given table. Index names of tables are cached, and will be
+// re-read from database once if any hinted index is not found in cache. If indexes could not be read, or no
+// index is found, for example, the table does not exist yet, the check will be skipped.
+func (d *xdb) checkIndexHints(ctx context.Context, table string, hints []*IndexHint) error {
+	if 0 == len(hints) || nil == d.db {
+		return nil
+	}
+
+	missing := func(names map[string]bool) string {
+		for _, h := range hints {
+			for _, idx := range h.Indexes {
+				if strings.ToUpper(idx) != "PRIMARY" && !names[idx] {
+					return idx
+				}
+			}
+		}
+		return ""
+	}
+
+	if v, exist := d.bufferedIndexNames.Load(table); exist {
+		if missing(v.(map[string]bool)) == "" {
+			return nil
+		}
+	}
+
+	indexes, uniques, err := d.readTableIndexes(ctx, table)
+	if err != nil || len(indexes)+len(uniques) == 0 {
+		return nil
+	}
+	names := make(map[string]bool, len(indexes)+len(uniques))
+	for name := range indexes {
+		names[name] = true
+	}
+	for name := range uniques {
+		names[name] = true
+	}
+	d.bufferedIndexNames.Store(table, names)
+
+	if idx := missing(names); idx != "" {
+		return fmt.Errorf("index '%s' not found in table '%s'", idx, table)
+	}
+	return nil
+}
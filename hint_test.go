@@ -0,0 +1,76 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHints(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	err = db.CreateTable(User{})
+	if err != nil {
+		t.Errorf("CreateTable error: %v", err)
+		return
+	}
+
+	var res []*User
+	err = db.Select(
+		&res, ForceIndex("index_fullname"), MaxExecutionTime(time.Second),
+		Condition("full_name", "=", "Mickey Mouse"), Options{DoNotExec: true},
+	)
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.HasPrefix(query, "SELECT /*+ MAX_EXECUTION_TIME(1000) */ `id`") ||
+		!strings.Contains(query, "FROM `t_user` FORCE INDEX (`index_fullname`) WHERE") {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	err = db.Select(&res, ForceIndex("index_fullname"), Condition("full_name", "=", "Mickey Mouse"))
+	if err != nil {
+		t.Errorf("Select error: %v", err)
+		return
+	}
+
+	// non-existed index
+	err = db.Select(&res, UseIndex("index_not_exist"), Condition("full_name", "=", "Mickey Mouse"))
+	if err == nil {
+		t.Errorf("error expected for non-existed index")
+		return
+	}
+	t.Logf("expected error: %v", err)
+
+	// UPDATE and DELETE
+	_, err = db.Update(
+		User{}, map[string]interface{}{"nation": "USA"},
+		UseIndex("index_fullname"), Condition("full_name", "=", "Mickey Mouse"), Options{DoNotExec: true},
+	)
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.HasPrefix(query, "UPDATE `t_user` USE INDEX (`index_fullname`) SET") {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = db.Delete(User{}, ForceIndex("index_fullname"), Condition("full_name", "=", "Mickey Mouse"))
+	if err == nil {
+		t.Errorf("error expected for index hints in DELETE")
+		return
+	}
+	_, err = db.Delete(
+		User{}, Hint("NO_INDEX(t_user index_fullname)"), Condition("full_name", "=", "Mickey Mouse"),
+		Options{DoNotExec: true},
+	)
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.HasPrefix(query, "DELETE /*+ NO_INDEX(t_user index_fullname) */ FROM `t_user` WHERE") {
+		t.Errorf("unexpected statement")
+		return
+	}
+}
@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -13,18 +14,20 @@ import (
 )
 
 var (
-	_timeRegex     = regexp.MustCompile(`^time(\d)$`)
-	_datetimeRegex = regexp.MustCompile(`^datetime(\d)$`)
+	_timeRegex     = regexp.MustCompile(`^time\((\d)\)$`)
+	_datetimeRegex = regexp.MustCompile(`^(?:datetime|timestamp)\((\d)\)$`)
 )
 
 // ========
 
 // InsertFields return keys and values for inserting, auto-increment fields will be ignored if its value is zero
 func (d *xdb) InsertFields(s interface{}, backQuoted bool) (keys []string, values []string, err error) {
-	return d.insertFields(s, backQuoted, false)
+	return d.insertFields(s, backQuoted, false, nil)
 }
 
-func (d *xdb) insertFields(s interface{}, backQuoted bool, ignoreNonZeroIncrement bool) (keys []string, values []string, err error) {
+func (d *xdb) insertFields(
+	s interface{}, backQuoted bool, ignoreNonZeroIncrement bool, b *binder,
+) (keys []string, values []string, err error) {
 	t := reflect.TypeOf(s)
 	v := reflect.ValueOf(s)
 
@@ -69,29 +72,42 @@ func (d *xdb) insertFields(s interface{}, backQuoted bool, ignoreNonZeroIncremen
 		}
 
 		var val string
+		var arg interface{}
 		intf := vf.Interface()
 		// log.Println("got field", fieldName)
 
 		switch intf.(type) {
 		case int, int8, int16, int32, int64:
 			val = strconv.FormatInt(vf.Int(), 10)
+			arg = vf.Int()
 		case uint, uint8, uint16, uint32, uint64:
 			val = strconv.FormatUint(vf.Uint(), 10)
+			arg = vf.Uint()
 		case string:
 			s := escapeValueString(vf.String())
 			val = addQuoteToString(s, "'")
+			arg = vf.String()
 		case bool:
 			val = convBoolToString(vf.Bool())
+			arg = vf.Bool()
 		case float32, float64:
 			val = fmt.Sprintf("%f", vf.Float())
+			arg = vf.Float()
 		case sql.NullString:
-			val = convNullStringToString(intf.(sql.NullString), "'")
+			ns := intf.(sql.NullString)
+			val = convNullStringToString(ns, "'")
+			if ns.Valid {
+				arg = ns.String
+			}
 		case sql.NullInt64:
 			val = convNullInt64ToString(intf.(sql.NullInt64))
+			arg = intf
 		case sql.NullBool:
 			val = convNullBoolToString(intf.(sql.NullBool))
+			arg = intf
 		case sql.NullFloat64:
 			val = convNullFloat64ToString(intf.(sql.NullFloat64))
+			arg = intf
 		case mysql.NullTime:
 			nt := intf.(mysql.NullTime)
 			if nt.Valid {
@@ -111,7 +127,7 @@ func (d *xdb) insertFields(s interface{}, backQuoted bool, ignoreNonZeroIncremen
 		default:
 			if reflect.Struct == tf.Type.Kind() {
 				// log.Println("Embedded struct: ", tf.Type)
-				embedKey, embedValue, err := d.insertFields(vf.Interface(), false, ignoreNonZeroIncrement)
+				embedKey, embedValue, err := d.insertFields(vf.Interface(), false, ignoreNonZeroIncrement, b)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -132,6 +148,13 @@ func (d *xdb) insertFields(s interface{}, backQuoted bool, ignoreNonZeroIncremen
 			}
 		}
 
+		switch intf.(type) {
+		case mysql.NullTime, sql.NullTime, time.Time:
+			val = b.bindTime(val)
+		default:
+			val = b.bind(val, arg)
+		}
+
 		keys = append(keys, fieldName)
 		values = append(values, val)
 		// continue
@@ -146,18 +169,32 @@ func (d *xdb) insertFields(s interface{}, backQuoted bool, ignoreNonZeroIncremen
 	return
 }
 
-// Insert insert a given structure. auto-increment fields will be ignored if its value is zero
+// Insert insert a given structure. auto-increment fields will be ignored if its value is zero. If a pointer is
+// given, the generated auto-increment ID will be written back into the structure.
 func (d *xdb) Insert(v interface{}, opts ...Options) (result sql.Result, err error) {
-	return d.insert(d.db, v, opts...)
+	return d.insert(context.Background(), d.db, v, opts...)
+}
+
+// InsertContext is the same as Insert, with a context.
+func (d *xdb) InsertContext(ctx context.Context, v interface{}, opts ...Options) (result sql.Result, err error) {
+	return d.insert(ctx, d.db, v, opts...)
 }
 
-func (d *xdb) insert(obj sqlObj, v interface{}, opts ...Options) (result sql.Result, err error) {
+func (d *xdb) insert(ctx context.Context, obj sqlObj, v interface{}, opts ...Options) (result sql.Result, err error) {
+	return d.insertWithMode(ctx, obj, insertModeInsert, v, opts...)
+}
+
+func (d *xdb) insertWithMode(
+	ctx context.Context, obj sqlObj, mode insertMode, v interface{}, opts ...Options,
+) (result sql.Result, err error) {
 	// Should be *Xxx or Xxx
 	ty := reflect.TypeOf(v)
 	va := reflect.ValueOf(v)
 	prototypeType := ty
+	var dst reflect.Value // auto-increment ID is written back if a pointer is given
 	// log.Printf("%v - %v\n", ty, ty.Kind())
 	if reflect.Ptr == ty.Kind() {
+		dst = va.Elem()
 		v = va.Elem().Interface()
 		ty = reflect.TypeOf(v)
 		va = reflect.ValueOf(v)
@@ -167,7 +204,8 @@ func (d *xdb) insert(obj sqlObj, v interface{}, opts ...Options) (result sql.Res
 		return nil, fmt.Errorf("parameter type invalid (%v)", prototypeType)
 	}
 
-	keys, values, err := d.insertFields(v, true, false)
+	b := d.newBinder()
+	keys, values, err := d.insertFields(v, true, false, b)
 	if err != nil {
 		return nil, err
 	}
@@ -177,24 +215,30 @@ func (d *xdb) insert(obj sqlObj, v interface{}, opts ...Options) (result sql.Res
 		return nil, fmt.Errorf("empty table name for type %v", reflect.TypeOf(v))
 	}
 
+	verb, err := packInsertVerb(mode, opt.Priority)
+	if err != nil {
+		return nil, err
+	}
+
 	// INSERT
-	query := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)", opt.TableName, strings.Join(keys, ", "), strings.Join(values, ", "))
+	query := fmt.Sprintf("%s `%s` (%s) VALUES (%s)", verb, opt.TableName, strings.Join(keys, ", "), strings.Join(values, ", "))
 	// log.Println(query)
 
 	if opt.DoNotExec {
-		err = newError(doNotExec, query)
+		err = newErrorWithArgs(doNotExec, query, b.args)
 		return
 	}
 
-	err = d.checkAutoCreateTable(v, opt)
+	err = d.checkAutoCreateTable(ctx, v, opt)
 	if err != nil {
 		return nil, err
 	}
-	result, err = obj.Exec(query)
+	result, err = obj.ExecContext(ctx, query, b.args...)
 	if err != nil {
-		err = newError(err.Error(), query)
+		err = newErrorWithArgs(err.Error(), query, b.args)
 		return
 	}
+	d.writeBackInsertID(dst, result)
 	return
 }
 
@@ -257,14 +301,14 @@ func convTimeToString(t time.Time, fieldMap map[string]*Field, fieldName string)
 		return t.Format("'2006'")
 	default:
 		if sub := _datetimeRegex.FindStringSubmatch(ty); sub != nil && len(sub) > 0 {
-			count, _ := strconv.Atoi(sub[0])
+			count, _ := strconv.Atoi(sub[1])
 			if 0 == count {
 				return t.Format("'2006-01-02 15:04:05'")
 			}
 			return t.Format("'2006-01-02 15:04:05." + strings.Repeat("0", count) + "'")
 
 		} else if sub := _timeRegex.FindStringSubmatch(ty); sub != nil && len(sub) > 0 {
-			count, _ := strconv.Atoi(sub[0])
+			count, _ := strconv.Atoi(sub[1])
 			if 0 == count {
 				return t.Format("'15:04:05'")
 			}
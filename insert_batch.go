@@ -0,0 +1,265 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// This file splits InsertMany statements into batches
+
+const (
+	// maxPlaceholders is the maximum number of '?' placeholders in one prepared statement
+	maxPlaceholders = 65535
+	// maxAllowedPacketReserved is reserved in @@max_allowed_packet for packet headers and protocol overhead
+	maxAllowedPacketReserved = 1024
+)
+
+// insertRow is a packed record in VALUES statement
+type insertRow struct {
+	values string
+	args   []interface{}
+	size   int
+	// record is the addressable structure value if a pointer is given
+	record reflect.Value
+}
+
+// packInsertRows packs each record in a slice value, which elements are Xxx or *Xxx. Keys are read from the
+// first record, and all records should have the same keys.
+func (d *xdb) packInsertRows(
+	va reflect.Value, isPtr bool, ignoreNonZeroIncrement bool,
+) (keys []string, rows []*insertRow, err error) {
+	rows = make([]*insertRow, 0, va.Len())
+	for i := 0; i < va.Len(); i++ {
+		var v interface{}
+		var record reflect.Value
+		if isPtr {
+			record = va.Index(i).Elem()
+			v = record.Interface()
+		} else {
+			v = va.Index(i).Interface()
+		}
+
+		b := d.newBinder()
+		k, values, err := d.insertFields(v, true, ignoreNonZeroIncrement, b)
+		if err != nil {
+			return nil, nil, err
+		}
+		if 0 == i {
+			keys = k
+		} else if !isStringsEqual(keys, k) {
+			// only happens when auto-increment values are given in some records
+			return nil, nil, fmt.Errorf(
+				"fields of record %d mismatch the first one, auto-increment values should be all given or all zero", i,
+			)
+		}
+
+		row := &insertRow{
+			values: "(" + strings.Join(values, ", ") + ")",
+			args:   b.args,
+			record: record,
+		}
+		row.size = len(row.values) + len(",\n") + argsSize(b.args)
+		rows = append(rows, row)
+	}
+	return keys, rows, nil
+}
+
+// isStringsEqual tells whether two string slices have the same elements in the same order
+func isStringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// argsSize estimates the size of placeholder arguments in a packet
+func argsSize(args []interface{}) int {
+	size := 0
+	for _, a := range args {
+		switch v := a.(type) {
+		case string:
+			size += len(v) + 9
+		case []byte:
+			size += len(v) + 9
+		default:
+			size += 8
+		}
+	}
+	return size
+}
+
+// splitInsertRows splits rows into batches. head and tail are the fixed parts of each statement, and tailArgs are
+// arguments in tail.
+func splitInsertRows(rows []*insertRow, head, tail string, tailArgs []interface{}, maxRows, maxBytes int) [][]*insertRow {
+	fixedSize := len(head) + len("\n") + len(tail) + argsSize(tailArgs)
+	var batches [][]*insertRow
+	var curr []*insertRow
+	currSize, currArgs := fixedSize, len(tailArgs)
+
+	for _, r := range rows {
+		if len(curr) > 0 {
+			exceeded := (maxRows > 0 && len(curr) >= maxRows) ||
+				(maxBytes > 0 && currSize+r.size > maxBytes) ||
+				currArgs+len(r.args) > maxPlaceholders
+			if exceeded {
+				batches = append(batches, curr)
+				curr = nil
+				currSize, currArgs = fixedSize, len(tailArgs)
+			}
+		}
+		curr = append(curr, r)
+		currSize += r.size
+		currArgs += len(r.args)
+	}
+	if len(curr) > 0 {
+		batches = append(batches, curr)
+	}
+	return batches
+}
+
+// maxAllowedPacket reads @@max_allowed_packet of the server. Zero is returned if it could not be read. The value
+// is read once and buffered, therefore changes of the global variable after that are not noticed.
+func (d *xdb) maxAllowedPacket(ctx context.Context, obj sqlObj) int {
+	if n := atomic.LoadInt64(&d.bufferedMaxAllowedPacket); n > 0 {
+		return int(n)
+	}
+	var res []int64
+	if err := obj.SelectContext(ctx, &res, "SELECT @@max_allowed_packet"); err != nil || 0 == len(res) {
+		return 0
+	}
+	atomic.StoreInt64(&d.bufferedMaxAllowedPacket, res[0])
+	return int(res[0])
+}
+
+// insertBatchLimits returns the maximum rows and bytes in one INSERT statement
+func (d *xdb) insertBatchLimits(ctx context.Context, obj sqlObj, opt Options) (maxRows, maxBytes int) {
+	maxRows = opt.MaxBatchRows
+	switch {
+	case opt.MaxBatchBytes > 0:
+		maxBytes = opt.MaxBatchBytes
+	case opt.MaxBatchBytes < 0 || opt.DoNotExec:
+		maxBytes = 0
+	default:
+		if n := d.maxAllowedPacket(ctx, obj); n > maxAllowedPacketReserved {
+			maxBytes = n - maxAllowedPacketReserved
+		}
+	}
+	return
+}
+
+// execInsertRows splits rows into batches and executes them. Each statement is head, rows and tail concatenated,
+// with rows separated by new lines. In DoNotExec mode, statements are joined by ";\n" in returned error.
+// If inserted is not nil, it is invoked with each executed batch and its result. If a batch fails outside a
+// transaction, the result of previous batches is returned together with the error.
+func (d *xdb) execInsertRows(
+	ctx context.Context, obj sqlObj, prototype interface{}, opt Options,
+	head string, rows []*insertRow, tail string, tailArgs []interface{},
+	inserted func(batch []*insertRow, res sql.Result),
+) (sql.Result, error) {
+	maxRows, maxBytes := d.insertBatchLimits(ctx, obj, opt)
+	batches := splitInsertRows(rows, head, tail, tailArgs, maxRows, maxBytes)
+
+	queries := make([]string, 0, len(batches))
+	queryArgs := make([][]interface{}, 0, len(batches))
+	for _, batch := range batches {
+		values := make([]string, 0, len(batch))
+		var args []interface{}
+		for _, r := range batch {
+			values = append(values, r.values)
+			args = append(args, r.args...)
+		}
+		args = append(args, tailArgs...)
+		queries = append(queries, head+"\n"+strings.Join(values, ",\n")+tail)
+		queryArgs = append(queryArgs, args)
+	}
+
+	if opt.DoNotExec {
+		var args []interface{}
+		for _, a := range queryArgs {
+			args = append(args, a...)
+		}
+		return nil, newErrorWithArgs(doNotExec, strings.Join(queries, ";\n"), args)
+	}
+
+	err := d.checkAutoCreateTable(ctx, prototype, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	// multiple batches are optionally executed in a transaction
+	var results []sql.Result
+	var execErr error
+	if db, ok := obj.(txBeginner); ok && opt.BatchInTx && len(queries) > 1 {
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		results, err = execInsertBatches(ctx, tx, queries, queryArgs)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err = tx.Commit(); err != nil {
+			return nil, err
+		}
+	} else {
+		results, execErr = execInsertBatches(ctx, obj, queries, queryArgs)
+		if execErr != nil && 0 == len(results) {
+			return nil, execErr
+		}
+	}
+
+	if inserted != nil {
+		for i, r := range results {
+			inserted(batches[i], r)
+		}
+	}
+	if 1 == len(results) && execErr == nil {
+		return results[0], nil
+	}
+	res := &multiResult{}
+	for _, r := range results {
+		if err = res.add(r); err != nil {
+			return nil, err
+		}
+	}
+	return res, execErr
+}
+
+// txBeginner is implemented by *sqlx.DB and *sqlx.Conn
+type txBeginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
+// execInsertBatches executes statements one by one. If one fails, results of previous ones are returned with the
+// error.
+func execInsertBatches(ctx context.Context, obj sqlObj, queries []string, queryArgs [][]interface{}) ([]sql.Result, error) {
+	results := make([]sql.Result, 0, len(queries))
+	for i, q := range queries {
+		r, err := execInsertBatch(ctx, obj, q, queryArgs[i])
+		if err != nil {
+			return results, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func execInsertBatch(ctx context.Context, obj sqlObj, query string, args []interface{}) (sql.Result, error) {
+	res, err := obj.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, newErrorWithArgs(err.Error(), query, args)
+	}
+	return res, nil
+}
@@ -0,0 +1,140 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+)
+
+// This file writes generated auto-increment IDs back into inserted structures.
+//
+// For InsertMany, IDs of a batch are calculated from LastInsertId, which is the ID of the first inserted row,
+// and @@auto_increment_increment. This relies on InnoDB allocating consecutive values for a multiple-row
+// 'INSERT ... VALUES' statement, which is guaranteed only by some values of @@innodb_autoinc_lock_mode:
+//
+//   - 0 (traditional) and 1 (consecutive): values of a "simple insert" with row count known in advance, such as
+//     the statements generated by InsertMany, are always consecutive.
+//   - 2 (interleaved, default since MySQL 8.0): values generated for one statement may not be consecutive if
+//     other statements are inserting into the same table concurrently.
+//
+// Therefore IDs of a multiple-row batch are only written back when @@innodb_autoinc_lock_mode is 0 or 1, and all
+// rows of the batch are inserted. Single-row batches are always written back by LastInsertId. Please do not rely
+// on it with storage engines other than InnoDB.
+
+// findStructField returns the field value with given field name in a structure value, including embedded
+// structures.
+func findStructField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tf := t.Field(i)
+		vf := v.Field(i)
+		if false == vf.CanInterface() {
+			continue
+		}
+
+		fieldName := getFieldName(&tf)
+		if fieldName == "-" {
+			continue
+		}
+		if fieldName == "" {
+			if tf.Type.Kind() == reflect.Struct {
+				if f, ok := findStructField(vf, name); ok {
+					return f, true
+				}
+			}
+			continue
+		}
+		if fieldName == name {
+			return vf, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// incrementFieldValue returns the settable auto-increment field value of a structure value, which is
+// unset yet. ok is false if there is no such field.
+func (d *xdb) incrementFieldValue(v reflect.Value) (fv reflect.Value, ok bool) {
+	if !v.IsValid() || !v.CanSet() {
+		return reflect.Value{}, false
+	}
+	f, err := d.getIncrementField(v.Interface())
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	fv, ok = findStructField(v, f.Name)
+	if !ok || !fv.CanSet() || !fv.IsZero() {
+		return reflect.Value{}, false
+	}
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fv, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// setIntValue sets an integer to an int or uint value
+func setIntValue(fv reflect.Value, n int64) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(n)
+	default:
+		fv.SetUint(uint64(n))
+	}
+}
+
+// writeBackInsertID sets LastInsertId of result to the auto-increment field of v, which is an addressable
+// structure value. Nothing is done if the field is already set.
+func (d *xdb) writeBackInsertID(v reflect.Value, res sql.Result) {
+	fv, ok := d.incrementFieldValue(v)
+	if !ok {
+		return
+	}
+	if id, err := res.LastInsertId(); err == nil && id > 0 {
+		setIntValue(fv, id)
+	}
+}
+
+// autoIncrementIncrement reads @@auto_increment_increment. It is session-scoped, therefore it should be read in
+// the connection which executes INSERT statements.
+func (d *xdb) autoIncrementIncrement(ctx context.Context, obj sqlObj) (int64, error) {
+	var res []int64
+	if err := obj.SelectContext(ctx, &res, "SELECT @@auto_increment_increment"); err != nil {
+		return 0, err
+	}
+	if 0 == len(res) || res[0] <= 0 {
+		return 1, nil
+	}
+	return res[0], nil
+}
+
+// consecutiveInsertIDs tells whether auto-increment values of a multiple-row INSERT statement are consecutive,
+// by reading @@innodb_autoinc_lock_mode. False is returned if it could not be read.
+func (d *xdb) consecutiveInsertIDs(ctx context.Context, obj sqlObj) bool {
+	var res []int64
+	if err := obj.SelectContext(ctx, &res, "SELECT @@innodb_autoinc_lock_mode"); err != nil || 0 == len(res) {
+		return false
+	}
+	return res[0] == 0 || res[0] == 1
+}
+
+// writeBackInsertIDs assigns consecutive IDs to records of a batch. It is invoked only if all rows are inserted.
+// Batches with multiple rows are skipped if IDs are not consecutive.
+func (d *xdb) writeBackInsertIDs(batch []*insertRow, res sql.Result, increment int64, consecutive bool) {
+	if !consecutive && len(batch) > 1 {
+		return
+	}
+	first, err := res.LastInsertId()
+	if err != nil || first <= 0 {
+		return
+	}
+	if n, err := res.RowsAffected(); err != nil || n != int64(len(batch)) {
+		return
+	}
+	for i, r := range batch {
+		if fv, ok := d.incrementFieldValue(r.record); ok {
+			setIntValue(fv, first+int64(i)*increment)
+		}
+	}
+}
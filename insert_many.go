@@ -1,22 +1,44 @@
 package mysqlx
 
 import (
-	"bytes"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"reflect"
 	"strings"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // InsertMany insert multiple records into table. If additional option with table name is not given,
 // mysqlx will use the FIRST table name in records for all. All auto-increment fields will be ignored.
+//
+// Records are split into multiple statements if they exceed MaxBatchRows or MaxBatchBytes in Options, or
+// @@max_allowed_packet of the server. RowsAffected of the result is the sum of all statements. If a statement fails
+// and BatchInTx is not set, the result of previous statements is returned together with the error.
+//
+// If records are given as []*Xxx or *[]*Xxx, generated auto-increment IDs will be written back into them. IDs of
+// statements with multiple rows are written back only if @@innodb_autoinc_lock_mode is 0 or 1, which guarantees
+// consecutive values.
 func (d *xdb) InsertMany(records interface{}, opts ...Options) (result sql.Result, err error) {
-	return d.insertMany(d.db, records, opts...)
+	return d.insertMany(context.Background(), d.db, records, opts...)
+}
+
+// InsertManyContext is the same as InsertMany, with a context.
+func (d *xdb) InsertManyContext(ctx context.Context, records interface{}, opts ...Options) (result sql.Result, err error) {
+	return d.insertMany(ctx, d.db, records, opts...)
+}
+
+func (d *xdb) insertMany(
+	ctx context.Context, obj sqlObj, records interface{}, opts ...Options,
+) (result sql.Result, err error) {
+	return d.insertManyWithMode(ctx, obj, insertModeInsert, records, opts...)
 }
 
-func (d *xdb) insertMany(obj sqlObj, records interface{}, opts ...Options) (result sql.Result, err error) {
+func (d *xdb) insertManyWithMode(
+	ctx context.Context, obj sqlObj, mode insertMode, records interface{}, opts ...Options,
+) (result sql.Result, err error) {
 	// records could be *[]*Xxx, []*Xxx, *[]Xxx, []Xxx
 
 	// firstly, get []*Xxx or []Xxx
@@ -32,7 +54,7 @@ func (d *xdb) insertMany(obj sqlObj, records interface{}, opts ...Options) (resu
 	}
 
 	total := va.Len()
-	log.Printf("%d record(s) given", total)
+	// log.Printf("%d record(s) given", total)
 	if 0 == total {
 		return nil, errors.New("no records provided")
 	}
@@ -58,63 +80,40 @@ func (d *xdb) insertMany(obj sqlObj, records interface{}, opts ...Options) (resu
 		return nil, fmt.Errorf("empty table name for type %v", reflect.TypeOf(v))
 	}
 
-	keys, values, err := d.insertFields(v, true, true)
-	if err != nil {
-		return
-	}
-
-	buffVal := bytes.Buffer{}
-	buffVal.WriteString(fmt.Sprintf(
-		"INSERT INTO `%s` (%s) VALUES\n",
-		opt.TableName,
-		strings.Join(keys, ", "),
-	))
-
-	writeValueToBuff(&buffVal, values)
-
-	// parse other records
-	for i := 1; i < total; i++ {
-		if isPtr {
-			v = va.Index(i).Elem().Interface()
-		} else {
-			v = va.Index(i).Interface()
-		}
-
-		_, values, err = d.insertFields(v, true, true)
-		if err != nil {
-			return
-		}
-		buffVal.WriteString(",\n")
-		writeValueToBuff(&buffVal, values)
-	}
-
-	query := buffVal.String()
-	if opt.DoNotExec {
-		return nil, newError(doNotExec, query)
-	}
-
-	err = d.checkAutoCreateTable(v, opt)
+	verb, err := packInsertVerb(mode, opt.Priority)
 	if err != nil {
 		return nil, err
 	}
-	result, err = obj.Exec(query)
+
+	keys, rows, err := d.packInsertRows(va, isPtr, true)
 	if err != nil {
-		err = newError(err.Error(), query)
 		return
 	}
-	return
-}
-
-func writeValueToBuff(buff *bytes.Buffer, values []string) {
-	buff.WriteRune('(')
 
-	for i, v := range values {
-		if i > 0 {
-			buff.WriteString(", ")
+	// auto-increment IDs are written back into []*Xxx records in INSERT statements
+	var inserted func([]*insertRow, sql.Result)
+	if isPtr && mode == insertModeInsert && !opt.DoNotExec {
+		if _, err = d.getIncrementField(v); err == nil {
+			// server variables are read in the same connection which executes the statements
+			if db, ok := obj.(*sqlx.DB); ok {
+				conn, err := db.Connx(ctx)
+				if err != nil {
+					return nil, err
+				}
+				defer conn.Close()
+				obj = conn
+			}
+			increment, err := d.autoIncrementIncrement(ctx, obj)
+			if err != nil {
+				return nil, err
+			}
+			consecutive := d.consecutiveInsertIDs(ctx, obj)
+			inserted = func(batch []*insertRow, res sql.Result) {
+				d.writeBackInsertIDs(batch, res, increment, consecutive)
+			}
 		}
-		buff.WriteString(v)
 	}
 
-	buff.WriteRune(')')
-	return
+	head := fmt.Sprintf("%s `%s` (%s) VALUES", verb, opt.TableName, strings.Join(keys, ", "))
+	return d.execInsertRows(ctx, obj, v, opt, head, rows, "", nil, inserted)
 }
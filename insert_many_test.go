@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"testing"
 )
 
@@ -33,3 +34,138 @@ func TestInsertMany(t *testing.T) {
 
 	return
 }
+
+func TestInsertManyInBatches(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	records := []*txTestRecord{{String: "batch"}, {String: "batch"}, {String: "batch"}}
+
+	// statements
+	_, err = db.InsertMany(records, Options{DoNotExec: true, MaxBatchRows: 2})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %v", query)
+	if query != "INSERT INTO `t_mysqlx_tx_test` (`f_string`) VALUES\n('batch'),\n('batch');\n"+
+		"INSERT INTO `t_mysqlx_tx_test` (`f_string`) VALUES\n('batch')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = db.InsertMany(records, Options{DoNotExec: true, MaxBatchBytes: 70})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %v", query)
+	if query != "INSERT INTO `t_mysqlx_tx_test` (`f_string`) VALUES\n('batch');\n"+
+		"INSERT INTO `t_mysqlx_tx_test` (`f_string`) VALUES\n('batch');\n"+
+		"INSERT INTO `t_mysqlx_tx_test` (`f_string`) VALUES\n('batch')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	// execution
+	db.MustCreateTable(&txTestRecord{})
+	res, err := db.InsertMany(records, Options{MaxBatchRows: 2, BatchInTx: true})
+	if err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 3 {
+		t.Errorf("three rows should be inserted, but got %d", n)
+		return
+	}
+
+	res, err = db.InsertManyOnDuplicateKeyUpdate(
+		records, map[string]interface{}{"f_string": "batch"}, Options{MaxBatchRows: 1},
+	)
+	if err != nil {
+		t.Errorf("InsertManyOnDuplicateKeyUpdate error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 3 {
+		t.Errorf("three rows should be inserted, but got %d", n)
+		return
+	}
+}
+
+func TestInsertWriteBackIDs(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+	db.MustCreateTable(&txTestRecord{})
+
+	r := txTestRecord{String: "write back"}
+	res, err := db.Insert(&r)
+	if err != nil {
+		t.Errorf("Insert error: %v", err)
+		return
+	}
+	if id, _ := res.LastInsertId(); id != r.ID {
+		t.Errorf("ID %d expected, but got %d", id, r.ID)
+		return
+	}
+
+	// IDs of multiple-row statements are written back only in consecutive lock modes
+	consecutive := db.(*xdb).consecutiveInsertIDs(context.Background(), db.Sqlx())
+	t.Logf("consecutive IDs: %v", consecutive)
+
+	records := []*txTestRecord{{String: "write back"}, {String: "write back"}, {String: "write back"}}
+	_, err = db.InsertMany(&records, Options{MaxBatchRows: 2})
+	if err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+	for i, r := range records {
+		if !consecutive && i < 2 {
+			if r.ID != 0 {
+				t.Errorf("ID of record %d should not be written back, but got %d", i, r.ID)
+				return
+			}
+			continue
+		}
+		var got txTestRecord
+		err = db.Get(&got, Condition("f_id", "=", r.ID))
+		if err != nil {
+			t.Errorf("Get record %d error: %v", r.ID, err)
+			return
+		}
+		if got.String != r.String {
+			t.Errorf("unexpected record %d: %+v", r.ID, got)
+			return
+		}
+	}
+}
+
+func TestInsertManyPartially(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+	db.MustCreateTable(&recForAutoIncTest{})
+	_, err = db.Delete(&recForAutoIncTest{}, Condition("string", "in", []string{"partial a", "partial b"}))
+	if err != nil {
+		t.Errorf("Delete error: %v", err)
+		return
+	}
+
+	// the last statement fails with duplicated unique key
+	records := []recForAutoIncTest{{String: "partial a"}, {String: "partial b"}, {String: "partial a"}}
+	res, err := db.InsertMany(records, Options{MaxBatchRows: 1})
+	if err == nil {
+		t.Errorf("error expected for duplicated unique key")
+		return
+	}
+	t.Logf("expected error: %v", err)
+	if res == nil {
+		t.Errorf("result of previous statements expected")
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 2 {
+		t.Errorf("two rows should be inserted, but got %d", n)
+		return
+	}
+}
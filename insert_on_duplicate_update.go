@@ -1,7 +1,7 @@
 package mysqlx
 
 import (
-	"bytes"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -14,12 +14,34 @@ import (
 func (d *xdb) InsertOnDuplicateKeyUpdate(
 	v interface{}, updates map[string]interface{}, opts ...Options,
 ) (result sql.Result, err error) {
-	return d.insertOnDuplicateKeyUpdate(d.db, v, updates, opts...)
+	return d.InsertOnDuplicateKeyUpdateResultContext(context.Background(), v, updates, opts...)
 }
 
-func (d *xdb) insertOnDuplicateKeyUpdate(
-	obj sqlObj, v interface{}, updates map[string]interface{}, opts ...Options,
+// InsertOnDuplicateKeyUpdateContext is the same as InsertOnDuplicateKeyUpdate, with a context.
+func (d *xdb) InsertOnDuplicateKeyUpdateContext(
+	ctx context.Context, v interface{}, updates map[string]interface{}, opts ...Options,
 ) (result sql.Result, err error) {
+	return d.InsertOnDuplicateKeyUpdateResultContext(ctx, v, updates, opts...)
+}
+
+// InsertOnDuplicateKeyUpdateResult is the same as InsertOnDuplicateKeyUpdate, but returns an UpsertResult. Outcome
+// of the returned result tells whether the record is inserted, updated or unchanged.
+func (d *xdb) InsertOnDuplicateKeyUpdateResult(
+	v interface{}, updates map[string]interface{}, opts ...Options,
+) (result UpsertResult, err error) {
+	return d.insertOnDuplicateKeyUpdate(context.Background(), d.db, v, updates, opts...)
+}
+
+// InsertOnDuplicateKeyUpdateResultContext is the same as InsertOnDuplicateKeyUpdateResult, with a context.
+func (d *xdb) InsertOnDuplicateKeyUpdateResultContext(
+	ctx context.Context, v interface{}, updates map[string]interface{}, opts ...Options,
+) (result UpsertResult, err error) {
+	return d.insertOnDuplicateKeyUpdate(ctx, d.db, v, updates, opts...)
+}
+
+func (d *xdb) insertOnDuplicateKeyUpdate(
+	ctx context.Context, obj sqlObj, v interface{}, updates map[string]interface{}, opts ...Options,
+) (result UpsertResult, err error) {
 
 	// Should be *Xxx or Xxx
 	ty := reflect.TypeOf(v)
@@ -37,7 +59,8 @@ func (d *xdb) insertOnDuplicateKeyUpdate(
 		return nil, fmt.Errorf("parameter type invalid (%v)", prototypeType)
 	}
 
-	keys, values, err := d.insertFields(v, true, false)
+	b := d.newBinder()
+	keys, values, err := d.insertFields(v, true, false, b)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +71,7 @@ func (d *xdb) insertOnDuplicateKeyUpdate(
 	}
 
 	// UPDATE parameters
-	updateKV, err := d.genUpdateKVs(v, updates)
+	updateKV, err := d.genUpdateKVs(v, updates, b, true)
 	if err != nil {
 		return nil, err
 	}
@@ -56,41 +79,72 @@ func (d *xdb) insertOnDuplicateKeyUpdate(
 		return nil, fmt.Errorf("no value specified")
 	}
 
+	verb, err := packInsertVerb(insertModeInsert, opt.Priority)
+	if err != nil {
+		return nil, err
+	}
+
 	// combine final sql statement
 	sql := fmt.Sprintf(
-		"INSERT INTO `%s` (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
-		opt.TableName,
+		"%s `%s` (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
+		verb, opt.TableName,
 		strings.Join(keys, ", "), strings.Join(values, ", "),
 		strings.Join(updateKV, ", "),
 	)
 	// log.Println(sql)
 	if opt.DoNotExec {
-		return nil, newError(doNotExec, sql)
+		return nil, newErrorWithArgs(doNotExec, sql, b.args)
 	}
 
-	err = d.checkAutoCreateTable(v, opt)
+	err = d.checkAutoCreateTable(ctx, v, opt)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err = obj.Exec(sql)
+	res, err := obj.ExecContext(ctx, sql, b.args...)
 	if err != nil {
-		err = newError(err.Error(), sql)
+		err = newErrorWithArgs(err.Error(), sql, b.args)
 		return
 	}
-	return
+	upsert := d.newUpsertResult(res)
+	upsert.add(1, res)
+	return upsert, nil
 }
 
 // InsertManyOnDuplicateKeyUpdate is similar with InsertOnDuplicateKeyUpdate, but insert mutiple records for onetime.
+// Records are split into multiple statements in the same way as InsertMany. Auto-increment values should be
+// given in all records or none of them.
 func (d *xdb) InsertManyOnDuplicateKeyUpdate(
 	records interface{}, updates map[string]interface{}, opts ...Options,
 ) (result sql.Result, err error) {
-	return d.insertManyOnDuplicateKeyUpdate(d.db, records, updates, opts...)
+	return d.InsertManyOnDuplicateKeyUpdateResultContext(context.Background(), records, updates, opts...)
 }
 
-func (d *xdb) insertManyOnDuplicateKeyUpdate(
-	obj sqlObj, records interface{}, updates map[string]interface{}, opts ...Options,
+// InsertManyOnDuplicateKeyUpdateContext is the same as InsertManyOnDuplicateKeyUpdate, with a context.
+func (d *xdb) InsertManyOnDuplicateKeyUpdateContext(
+	ctx context.Context, records interface{}, updates map[string]interface{}, opts ...Options,
 ) (result sql.Result, err error) {
+	return d.InsertManyOnDuplicateKeyUpdateResultContext(ctx, records, updates, opts...)
+}
+
+// InsertManyOnDuplicateKeyUpdateResult is the same as InsertManyOnDuplicateKeyUpdate, but returns an
+// UpsertResult. Counters of each statement could be read by Batches of the returned result.
+func (d *xdb) InsertManyOnDuplicateKeyUpdateResult(
+	records interface{}, updates map[string]interface{}, opts ...Options,
+) (result UpsertResult, err error) {
+	return d.insertManyOnDuplicateKeyUpdate(context.Background(), d.db, records, updates, opts...)
+}
+
+// InsertManyOnDuplicateKeyUpdateResultContext is the same as InsertManyOnDuplicateKeyUpdateResult, with a context.
+func (d *xdb) InsertManyOnDuplicateKeyUpdateResultContext(
+	ctx context.Context, records interface{}, updates map[string]interface{}, opts ...Options,
+) (result UpsertResult, err error) {
+	return d.insertManyOnDuplicateKeyUpdate(ctx, d.db, records, updates, opts...)
+}
+
+func (d *xdb) insertManyOnDuplicateKeyUpdate(
+	ctx context.Context, obj sqlObj, records interface{}, updates map[string]interface{}, opts ...Options,
+) (result UpsertResult, err error) {
 
 	// records could be *[]*Xxx, []*Xxx, *[]Xxx, []Xxx
 
@@ -133,13 +187,19 @@ func (d *xdb) insertManyOnDuplicateKeyUpdate(
 		return nil, fmt.Errorf("empty table name for type %v", reflect.TypeOf(v))
 	}
 
-	keys, values, err := d.insertFields(v, true, false)
+	verb, err := packInsertVerb(insertModeInsert, opt.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, rows, err := d.packInsertRows(va, isPtr, false)
 	if err != nil {
 		return
 	}
 
 	// UPDATE parameters
-	updateKV, err := d.genUpdateKVs(v, updates)
+	b := d.newBinder()
+	updateKV, err := d.genUpdateKVs(v, updates, b, true)
 	if err != nil {
 		return nil, err
 	}
@@ -147,54 +207,15 @@ func (d *xdb) insertManyOnDuplicateKeyUpdate(
 		return nil, fmt.Errorf("no value specified")
 	}
 
-	buffVal := bytes.Buffer{}
-	buffVal.WriteString(fmt.Sprintf(
-		"INSERT INTO `%s` (%s) VALUES\n",
-		opt.TableName,
-		strings.Join(keys, ", "),
-	))
-
-	writeValueToBuff(&buffVal, values)
-
-	// parse other records
-	for i := 1; i < total; i++ {
-		if isPtr {
-			v = va.Index(i).Elem().Interface()
-		} else {
-			v = va.Index(i).Interface()
-		}
-
-		_, values, err = d.insertFields(v, true, true)
-		if err != nil {
-			return
-		}
-		buffVal.WriteString(",\n")
-		writeValueToBuff(&buffVal, values)
-	}
-
-	buffVal.WriteString("\nON DUPLICATE KEY UPDATE\n")
-
-	for i, kv := range updateKV {
-		if i > 0 {
-			buffVal.WriteString(", ")
-		}
-		buffVal.WriteString(kv)
-	}
-
-	// combine final sql statement
-	query := buffVal.String()
-	if opt.DoNotExec {
-		return nil, newError(doNotExec, query)
-	}
-
-	err = d.checkAutoCreateTable(v, opt)
-	if err != nil {
+	head := fmt.Sprintf("%s `%s` (%s) VALUES", verb, opt.TableName, strings.Join(keys, ", "))
+	tail := "\nON DUPLICATE KEY UPDATE\n" + strings.Join(updateKV, ", ")
+	upsert := d.newUpsertResult(nil)
+	res, err := d.execInsertRows(ctx, obj, v, opt, head, rows, tail, b.args, func(batch []*insertRow, res sql.Result) {
+		upsert.add(len(batch), res)
+	})
+	if nil == res {
 		return nil, err
 	}
-	result, err = obj.Exec(query)
-	if err != nil {
-		err = newError(err.Error(), query)
-		return
-	}
-	return
+	upsert.Result = res
+	return upsert, err
 }
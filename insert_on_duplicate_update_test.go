@@ -165,3 +165,96 @@ func TestInsertManyOnDuplicateKeyUpdate(t *testing.T) {
 
 	return
 }
+
+func TestUpsertOutcome(t *testing.T) {
+	// outcomes by affected rows
+	cases := []struct {
+		clientFoundRows bool
+		records         int
+		affected        int64
+		expected        UpsertOutcome
+	}{
+		{false, 1, 1, UpsertInserted},
+		{false, 1, 2, UpsertUpdated},
+		{false, 1, 0, UpsertUnchanged},
+		{false, 2, 2, UpsertUnknown},
+		{true, 1, 1, UpsertInsertedOrUnchanged},
+		{true, 1, 2, UpsertUpdated},
+		{true, 2, 3, UpsertUnknown},
+	}
+	for _, c := range cases {
+		r := &upsertResult{clientFoundRows: c.clientFoundRows}
+		r.add(c.records, &multiResult{rowsAffected: c.affected})
+		if o := r.Outcome(); o != c.expected {
+			t.Errorf("%+v: unexpected outcome '%v'", c, o)
+			return
+		}
+	}
+
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+	db.Sqlx().Exec("DROP TABLE `t_department`")
+	db.AutoCreateTable()
+
+	dept := department{Dept: "Outcome", Desc: "inserted"}
+	upsert := func(desc string) UpsertOutcome {
+		res, err := db.InsertOnDuplicateKeyUpdateResult(&dept, map[string]interface{}{"f_desc": desc})
+		if err != nil {
+			t.Errorf("InsertOnDuplicateKeyUpdateResult error: %v", err)
+			return UpsertUnknown
+		}
+		return res.Outcome()
+	}
+	if o := upsert("inserted"); o != UpsertInserted {
+		t.Errorf("unexpected outcome '%v'", o)
+		return
+	}
+	if o := upsert("updated"); o != UpsertUpdated {
+		t.Errorf("unexpected outcome '%v'", o)
+		return
+	}
+	if o := upsert("updated"); o != UpsertUnchanged {
+		t.Errorf("unexpected outcome '%v'", o)
+		return
+	}
+
+	depts := []*department{{Dept: "Outcome", Desc: "many"}, {Dept: "Outcome 2", Desc: "many"}}
+	res, err := db.InsertManyOnDuplicateKeyUpdateResult(
+		depts, map[string]interface{}{"f_desc": Values("f_desc")}, Options{MaxBatchRows: 1},
+	)
+	if err != nil {
+		t.Errorf("InsertManyOnDuplicateKeyUpdateResult error: %v", err)
+		return
+	}
+	batches := res.Batches()
+	t.Logf("batches: %+v", batches)
+	if len(batches) != 2 || batches[0].Updated != 1 || batches[1].RowsAffected != 1 {
+		t.Errorf("unexpected batches")
+		return
+	}
+}
+
+func TestInsertManyOnDuplicateKeyUpdateMixedIDs(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	records := []txTestRecord{{ID: 1, String: "mixed"}, {String: "mixed"}}
+	_, err = db.InsertManyOnDuplicateKeyUpdate(
+		records, map[string]interface{}{"f_string": "mixed"}, Options{DoNotExec: true},
+	)
+	if query := GetQueryFromError(err); query != "" {
+		t.Errorf("error expected, but got statement: %s", query)
+		return
+	}
+	if err == nil {
+		t.Errorf("error expected for mixed auto-increment values")
+		return
+	}
+	t.Logf("expected error: %v", err)
+}
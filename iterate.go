@@ -0,0 +1,65 @@
+package mysqlx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Iterate executes a SQL select statement just like Select, but scans records one by one into a new pointer to
+// prototype structure, and then invokes callback with it. For example, if prototype is User{} or &User{}, the
+// parameter row in callback would be a *User. Iteration stops and returns the error once callback returns one.
+//
+// This is useful for scanning large result sets, which could not be loaded into memory at once.
+func (d *xdb) Iterate(prototype interface{}, callback func(row interface{}) error, args ...interface{}) error {
+	return d.iterate(context.Background(), d.db, prototype, callback, args...)
+}
+
+// IterateContext is the same as Iterate, with a context. Rows will be closed once the context is canceled.
+func (d *xdb) IterateContext(
+	ctx context.Context, prototype interface{}, callback func(row interface{}) error, args ...interface{},
+) error {
+	return d.iterate(ctx, d.db, prototype, callback, args...)
+}
+
+func (d *xdb) iterate(
+	ctx context.Context, obj sqlObj, prototype interface{}, callback func(row interface{}) error, args ...interface{},
+) error {
+	if nil == callback {
+		return fmt.Errorf("nil callback")
+	}
+	prototype, err := getStructPrototype(prototype)
+	if err != nil {
+		return err
+	}
+	ty := reflect.TypeOf(prototype)
+
+	query, queryArgs, opt, err := d.packSelect(ctx, obj, ty, args)
+	if err != nil {
+		return err
+	}
+	if opt.DoNotExec {
+		return newErrorWithArgs(doNotExec, query, queryArgs)
+	}
+
+	rows, err := obj.QueryxContext(ctx, query, queryArgs...)
+	if err != nil {
+		return newErrorWithArgs(err.Error(), query, queryArgs)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := reflect.New(ty)
+		if err = rows.StructScan(row.Interface()); err != nil {
+			return newErrorWithArgs(err.Error(), query, queryArgs)
+		}
+		if err = callback(row.Interface()); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return newErrorWithArgs(err.Error(), query, queryArgs)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package mysqlx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIterate(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	d.MustCreateTable(&txTestRecord{})
+	_, err = d.InsertMany([]txTestRecord{
+		{String: "iterate"}, {String: "iterate"}, {String: "iterate"},
+	})
+	if err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+
+	count := 0
+	err = d.Iterate(txTestRecord{}, func(row interface{}) error {
+		r, ok := row.(*txTestRecord)
+		if !ok {
+			t.Errorf("unexpected row type %T", row)
+			return errors.New("type error")
+		}
+		if r.String != "iterate" {
+			t.Errorf("unexpected record %+v", r)
+		}
+		count++
+		return nil
+	}, Condition("f_string", "=", "iterate"), &Order{Param: "f_id", Seq: "DESC"})
+	if err != nil {
+		t.Errorf("Iterate error: %v", err)
+		return
+	}
+	if count < 3 {
+		t.Errorf("at least 3 records expected, but got %d", count)
+		return
+	}
+
+	// stop early
+	stop := errors.New("stop")
+	count = 0
+	err = d.Iterate(&txTestRecord{}, func(row interface{}) error {
+		count++
+		return stop
+	}, Condition("f_string", "=", "iterate"))
+	if !errors.Is(err, stop) {
+		t.Errorf("callback error expected, but got %v", err)
+		return
+	}
+	if count != 1 {
+		t.Errorf("iteration should stop at the first record, but got %d", count)
+		return
+	}
+}
@@ -0,0 +1,294 @@
+package mysqlx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Join identifies a JOIN statement in Select. To select with JOIN, the destination structure should contains
+// structure fields with db tags, each of them represents a table and the tag is used as the table alias. For
+// example:
+//
+//	type UserOrder struct {
+//		User  User  `db:"u"`
+//		Order Order `db:"o"`
+//	}
+//
+//	var res []UserOrder
+//	err := db.Select(&res, mysqlx.InnerJoin("o", "u.id", "o.user_id"), mysqlx.Condition("u.id", "=", 1))
+//
+// The first table structure in the destination which is not joined by any Join argument is used as the FROM table.
+// Fields in conditions could be qualified by aliases, like "u.id". As the alias could be the same as the table
+// name, tag the structure field with its table name to use names like "t_user.id".
+//
+// Please note that fields of LEFT JOIN tables may be NULL. Use nullable types in those structures.
+type Join struct {
+	// Type is the JOIN type, such as "INNER" or "LEFT"
+	Type string
+	// Alias is the db tag of the joined table structure in destination
+	Alias string
+	// On holds pairs of field names in ON statement
+	On [][2]string
+}
+
+// InnerJoin returns a Join with INNER JOIN type. Fields should be qualified by aliases.
+func InnerJoin(alias, leftField, rightField string) *Join {
+	return &Join{
+		Type:  "INNER",
+		Alias: alias,
+		On:    [][2]string{{leftField, rightField}},
+	}
+}
+
+// LeftJoin returns a Join with LEFT JOIN type. Fields should be qualified by aliases.
+func LeftJoin(alias, leftField, rightField string) *Join {
+	return &Join{
+		Type:  "LEFT",
+		Alias: alias,
+		On:    [][2]string{{leftField, rightField}},
+	}
+}
+
+// And adds another pair of fields into ON statement with AND logic
+func (j *Join) And(leftField, rightField string) *Join {
+	j.On = append(j.On, [2]string{leftField, rightField})
+	return j
+}
+
+func (j *Join) pack(table string, fieldMap map[string]*Field, hints []*IndexHint) (string, error) {
+	typ := strings.ToUpper(j.Type)
+	switch typ {
+	case "INNER", "LEFT", "RIGHT", "CROSS":
+		// OK
+	default:
+		return "", fmt.Errorf("unsupported JOIN type '%s'", j.Type)
+	}
+	if 0 == len(j.On) {
+		return "", fmt.Errorf("no ON fields given for JOIN '%s'", j.Alias)
+	}
+
+	on := make([]string, 0, len(j.On))
+	for _, pair := range j.On {
+		for _, f := range pair {
+			if _, exist := fieldMap[f]; !exist || !strings.Contains(f, ".") {
+				return "", fmt.Errorf("field '%s' in JOIN '%s' not recognized", f, j.Alias)
+			}
+		}
+		on = append(on, quoteField(pair[0])+" = "+quoteField(pair[1]))
+	}
+
+	// index hints are already checked in parseArgs
+	indexHints, _ := packIndexHints(hints)
+	return fmt.Sprintf(
+		"%s JOIN %s ON %s", typ, joinClauses(tableWithAlias(table, j.Alias), indexHints), strings.Join(on, " AND "),
+	), nil
+}
+
+func tableWithAlias(table, alias string) string {
+	if table == alias {
+		return "`" + table + "`"
+	}
+	return "`" + table + "` AS `" + alias + "`"
+}
+
+func hasJoin(args []interface{}) bool {
+	for _, arg := range args {
+		switch arg.(type) {
+		case Join, *Join:
+			return true
+		}
+	}
+	return false
+}
+
+type joinPart struct {
+	alias     string
+	table     string
+	prototype interface{}
+	fields    []*Field
+}
+
+// readJoinParts reads table structures in a JOIN result structure
+func (d *xdb) readJoinParts(ty reflect.Type) ([]*joinPart, error) {
+	var parts []*joinPart
+
+	for i := 0; i < ty.NumField(); i++ {
+		tf := ty.Field(i)
+		if tf.PkgPath != "" {
+			continue // unexported
+		}
+		alias := getFieldName(&tf)
+		if alias == "" || alias == "-" {
+			continue
+		}
+
+		ft := tf.Type
+		if reflect.Ptr == ft.Kind() {
+			ft = ft.Elem()
+		}
+		if reflect.Struct != ft.Kind() {
+			continue
+		}
+
+		prototype := reflect.New(ft).Elem().Interface()
+		fields, err := d.ReadStructFields(prototype)
+		if err != nil {
+			return nil, err
+		}
+		if 0 == len(fields) {
+			continue // not a table structure, such as time.Time
+		}
+
+		opt := mergeOptions(prototype)
+		if "" == opt.TableName {
+			return nil, fmt.Errorf("empty table name for type %v", ft)
+		}
+
+		parts = append(parts, &joinPart{
+			alias:     alias,
+			table:     opt.TableName,
+			prototype: prototype,
+			fields:    fields,
+		})
+	}
+
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("at least two table structures should be given in type %v for JOIN", ty)
+	}
+	return parts, nil
+}
+
+// packJoinSelect packs SELECT statement with JOIN for given result structure type
+func (d *xdb) packJoinSelect(
+	ctx context.Context, obj sqlObj, ty reflect.Type, args []interface{},
+) (query string, queryArgs []interface{}, opt Options, err error) {
+	parts, err := d.readJoinParts(ty)
+	if err != nil {
+		return
+	}
+
+	// field map with qualified field names. Unqualified names are also added if not ambiguous.
+	fieldMap := map[string]*Field{}
+	ambiguous := map[string]bool{}
+	for _, p := range parts {
+		for _, f := range p.fields {
+			fieldMap[p.alias+"."+f.Name] = f
+			if _, exist := fieldMap[f.Name]; exist {
+				ambiguous[f.Name] = true
+			} else {
+				fieldMap[f.Name] = f
+			}
+		}
+	}
+	for name := range ambiguous {
+		delete(fieldMap, name)
+	}
+
+	parsedArgs, err := d.parseArgs(parts[0].prototype, fieldMap, args)
+	if err != nil {
+		return
+	}
+	if err = checkLockingRead(obj, parsedArgs); err != nil {
+		return
+	}
+
+	// pack FROM and JOIN statements
+	joinedParts := map[string]*Join{}
+	for _, j := range parsedArgs.Joins {
+		if _, exist := joinedParts[j.Alias]; exist {
+			err = fmt.Errorf("duplicated JOIN '%s'", j.Alias)
+			return
+		}
+		joinedParts[j.Alias] = j
+	}
+
+	var from *joinPart
+	partMap := map[string]*joinPart{}
+	for _, p := range parts {
+		if _, exist := partMap[p.alias]; exist {
+			err = fmt.Errorf("duplicated table alias '%s'", p.alias)
+			return
+		}
+		partMap[p.alias] = p
+		if _, joined := joinedParts[p.alias]; !joined && from == nil {
+			from = p
+		}
+	}
+	if from == nil {
+		err = fmt.Errorf("no table to select from in type %v", ty)
+		return
+	}
+
+	// group index hints by table aliases
+	hints := map[string][]*IndexHint{}
+	for _, h := range parsedArgs.IndexHints {
+		alias := h.Table
+		if alias == "" {
+			alias = from.alias
+		}
+		p, exist := partMap[alias]
+		if !exist {
+			err = fmt.Errorf("table '%s' in index hint not found", alias)
+			return
+		}
+		if !parsedArgs.Opt.DoNotExec {
+			if err = d.checkIndexHints(ctx, p.table, []*IndexHint{h}); err != nil {
+				return
+			}
+		}
+		hints[alias] = append(hints[alias], h)
+	}
+
+	fromHints, _ := packIndexHints(hints[from.alias])
+	clauses := []string{joinClauses(tableWithAlias(from.table, from.alias), fromHints)}
+	for _, j := range parsedArgs.Joins {
+		p, exist := partMap[j.Alias]
+		if !exist {
+			err = fmt.Errorf("JOIN alias '%s' not found in type %v", j.Alias, ty)
+			return
+		}
+		var s string
+		s, err = j.pack(p.table, fieldMap, hints[j.Alias])
+		if err != nil {
+			return
+		}
+		if f := d.softDeleteField(p.prototype); f != nil && !parsedArgs.unscoped {
+			s += " AND " + notDeletedCond(f, p.alias+"."+f.Name)
+		}
+		clauses = append(clauses, s)
+	}
+	for _, p := range parts {
+		if _, joined := joinedParts[p.alias]; !joined && p != from {
+			err = fmt.Errorf("table '%s' is neither selected from nor joined", p.alias)
+			return
+		}
+	}
+	parsedArgs.from = strings.Join(clauses, " ")
+	if f := d.softDeleteField(from.prototype); f != nil && !parsedArgs.unscoped {
+		parsedArgs.CondList = append(parsedArgs.CondList, notDeletedCond(f, from.alias+"."+f.Name))
+	}
+
+	// pack selected fields
+	var selected []string
+	if len(parsedArgs.Columns) > 0 {
+		for _, c := range parsedArgs.Columns {
+			if _, exist := fieldMap[c]; !exist || !strings.Contains(c, ".") {
+				err = fmt.Errorf("field '%s' not recognized, fields should be qualified in JOIN", c)
+				return
+			}
+			selected = append(selected, quoteField(c)+" AS `"+c+"`")
+		}
+	} else {
+		for _, p := range parts {
+			for _, f := range p.fields {
+				name := p.alias + "." + f.Name
+				selected = append(selected, quoteField(name)+" AS `"+name+"`")
+			}
+		}
+	}
+
+	query, queryArgs = packSelectQuery(strings.Join(selected, ", "), parsedArgs)
+	return query, queryArgs, parsedArgs.Opt, nil
+}
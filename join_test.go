@@ -0,0 +1,112 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+)
+
+type joinTestOrder struct {
+	ID     int64  `db:"id"       mysqlx:"increment:true"`
+	UserID int32  `db:"user_id"`
+	Item   string `db:"item"     mysqlx:"type:varchar(64)"`
+}
+
+func (joinTestOrder) Options() Options {
+	return Options{
+		TableName: "t_mysqlx_join_order",
+		Indexes: []Index{
+			{Fields: []string{"user_id"}},
+		},
+	}
+}
+
+type joinTestUserOrder struct {
+	User  User          `db:"t_user"`
+	Order joinTestOrder `db:"o"`
+}
+
+type joinTestSelf struct {
+	Left  User `db:"l"`
+	Right User `db:"r"`
+}
+
+func TestJoin(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	db.MustCreateTable(User{})
+	db.MustCreateTable(joinTestOrder{})
+
+	u := User{FullName: "Join Test", Gender: "Female"}
+	res, err := db.Insert(u)
+	if err != nil {
+		t.Errorf("Insert user error: %v", err)
+		return
+	}
+	uid, _ := res.LastInsertId()
+
+	_, err = db.InsertMany([]joinTestOrder{
+		{UserID: int32(uid), Item: "apple"},
+		{UserID: int32(uid), Item: "banana"},
+	})
+	if err != nil {
+		t.Errorf("InsertMany orders error: %v", err)
+		return
+	}
+
+	var list []*joinTestUserOrder
+	args := []interface{}{
+		InnerJoin("o", "t_user.id", "o.user_id"),
+		Condition("t_user.id", "=", uid),
+		&Order{Param: "o.id", Seq: "ASC"},
+	}
+	err = db.Select(&list, append(args, Options{DoNotExec: true})...)
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.Contains(query, "INNER JOIN `t_mysqlx_join_order` AS `o` ON `t_user`.`id` = `o`.`user_id`") {
+		t.Errorf("unexpected JOIN statement")
+		return
+	}
+
+	err = db.Select(&list, args...)
+	if err != nil {
+		t.Errorf("Select with JOIN error: %v", err)
+		return
+	}
+	if len(list) != 2 {
+		t.Errorf("expected 2 records but got %d", len(list))
+		return
+	}
+	for _, r := range list {
+		if r.User.FullName != u.FullName || int64(r.Order.UserID) != uid {
+			t.Errorf("unexpected record: %+v", r)
+			return
+		}
+	}
+
+	// self join
+	var self []joinTestSelf
+	err = db.Select(&self,
+		InnerJoin("r", "l.full_name", "r.full_name"),
+		Condition("l.id", "=", uid),
+	)
+	if err != nil {
+		t.Errorf("Select with self JOIN error: %v", err)
+		return
+	}
+	if len(self) == 0 || self[0].Left.FullName != self[0].Right.FullName {
+		t.Errorf("unexpected self JOIN result: %+v", self)
+		return
+	}
+
+	// invalid ON field
+	err = db.Select(&list, InnerJoin("o", "t_user.id", "o.no_such_field"))
+	if err == nil {
+		t.Errorf("error expected for invalid ON field")
+		return
+	}
+	t.Logf("expected error: %v", err)
+}
@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -21,8 +22,9 @@ type dbInfo struct {
 }
 
 type sqlObj interface {
-	Exec(query string, args ...interface{}) (sql.Result, error)
-	Select(dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
 }
 
 // xdb is the main structure for mysqlx
@@ -39,6 +41,10 @@ type xdb struct {
 	bufferedFieldMaps    sync.Map // map[string]*Field
 	bufferedSelectFields sync.Map // []string
 	bufferedIncrField    sync.Map // *Field
+	bufferedIndexNames   sync.Map // map[string]bool
+
+	// server variables, read by atomic operations
+	bufferedMaxAllowedPacket int64
 
 	// stores created tables
 	autoCreateTable atomicbool.B
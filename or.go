@@ -4,25 +4,14 @@ import (
 	"strings"
 )
 
-// Or packages conditions with OR logic. Only Cond, *Cond, Or, And types are acceptable in the slice.
+// Or packages conditions with OR logic. Only Cond, *Cond, Or, And, Exists types are acceptable in the slice.
 type Or []interface{}
 
-func (or Or) pack(fieldMap map[string]*Field) string {
+func (or Or) pack(fieldMap map[string]*Field, b *binder) string {
 	statements := make([]string, 0, len(or))
 	for _, v := range or {
-		s := ""
-
-		switch v.(type) {
-		case Cond:
-			c := v.(Cond)
-			s = c.pack(fieldMap)
-		case *Cond:
-			s = v.(*Cond).pack(fieldMap)
-		case Or:
-			s = v.(Or).pack(fieldMap)
-		case And:
-			s = v.(And).pack(fieldMap)
-		default:
+		s, ok := packCondition(v, fieldMap, b)
+		if !ok {
 			continue
 		}
 
@@ -13,5 +13,5 @@ func (o *Order) pack() string {
 		return ""
 	}
 
-	return fmt.Sprintf("`%s` %s", o.Param, o.Seq)
+	return fmt.Sprintf("%s %s", quoteField(o.Param), o.Seq)
 }
@@ -0,0 +1,330 @@
+package mysqlx
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Pagination describes a page request of keyset (seek) pagination.
+type Pagination struct {
+	// Keys are fields to sort records. All keys should be in the same sequence, and the combination of them should
+	// be unique, for example (created_at, id). Values of keys should not be NULL.
+	Keys []Order
+	// Cursor is the opaque token returned by PageResult. Leave it empty for the first page.
+	Cursor string
+	// Size is the maximum number of records in one page.
+	Size int
+}
+
+// PageResult is returned by Paginate. Next and Prev are cursors for the next and previous pages. They are empty
+// if no more pages in that direction.
+type PageResult struct {
+	Next string
+	Prev string
+}
+
+type pageCursor struct {
+	Backward bool          `json:"b,omitempty"`
+	Values   []interface{} `json:"v"`
+}
+
+// Paginate selects one page of records into dst by keyset pagination, which uses conditions like
+// "(`a`, `b`) > (?, ?)" instead of OFFSET. Other arguments are the same as Select, except Order, Limit and Offset.
+// If Columns are given, they should include all keys.
+func (d *xdb) Paginate(dst interface{}, page Pagination, args ...interface{}) (*PageResult, error) {
+	return d.paginate(context.Background(), d.db, dst, page, args...)
+}
+
+// PaginateContext is the same as Paginate, with a context.
+func (d *xdb) PaginateContext(
+	ctx context.Context, dst interface{}, page Pagination, args ...interface{},
+) (*PageResult, error) {
+	return d.paginate(ctx, d.db, dst, page, args...)
+}
+
+func (d *xdb) paginate(
+	ctx context.Context, obj sqlObj, dst interface{}, page Pagination, args ...interface{},
+) (*PageResult, error) {
+	ty, err := getSliceElemStructType(dst)
+	if err != nil {
+		return nil, err
+	}
+	if page.Size <= 0 {
+		return nil, fmt.Errorf("invalid page size %d", page.Size)
+	}
+	var columns []string
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case Order, *Order, OrderExpr, *OrderExpr, Limit, Offset:
+			return nil, fmt.Errorf("%T is not allowed in Paginate", arg)
+		case ColumnsType:
+			columns = append(columns, a...)
+		case *ColumnsType:
+			columns = append(columns, (*a)...)
+		}
+	}
+
+	// check keys
+	prototype := reflect.New(ty).Elem().Interface()
+	fieldMap, err := d.getFieldMap(prototype)
+	if err != nil {
+		return nil, err
+	}
+	if 0 == len(page.Keys) {
+		return nil, fmt.Errorf("no pagination keys given")
+	}
+	fields := make([]string, 0, len(page.Keys))
+	desc := strings.ToUpper(page.Keys[0].Seq) == "DESC"
+	for _, k := range page.Keys {
+		if _, exist := fieldMap[k.Param]; !exist {
+			return nil, fmt.Errorf("field '%s' not recognized", k.Param)
+		}
+		if (strings.ToUpper(k.Seq) == "DESC") != desc {
+			return nil, fmt.Errorf("all pagination keys should be in the same sequence")
+		}
+		fields = append(fields, k.Param)
+	}
+
+	// keys should be selected to generate cursors
+	if len(columns) > 0 {
+		selected := make(map[string]bool, len(columns))
+		for _, c := range columns {
+			selected[c] = true
+		}
+		for _, f := range fields {
+			if !selected[f] {
+				return nil, fmt.Errorf("pagination key '%s' is not in Columns", f)
+			}
+		}
+	}
+
+	// parse cursor
+	cursor := pageCursor{}
+	if page.Cursor != "" {
+		cursor, err = decodePageCursor(page.Cursor, prototype, fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// pack arguments
+	seq := "ASC"
+	if desc != cursor.Backward {
+		seq = "DESC"
+	}
+	newArgs := make([]interface{}, 0, len(args)+len(fields)+2)
+	newArgs = append(newArgs, args...)
+	if page.Cursor != "" {
+		operator := ">"
+		if seq == "DESC" {
+			operator = "<"
+		}
+		newArgs = append(newArgs, &rowCond{Params: fields, Operator: operator, Values: cursor.Values})
+	}
+	for _, f := range fields {
+		newArgs = append(newArgs, &Order{Param: f, Seq: seq})
+	}
+	newArgs = append(newArgs, Limit(page.Size+1))
+
+	err = d.selectFunc(ctx, obj, dst, newArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// trim and reorder records
+	list := reflect.ValueOf(dst).Elem()
+	hasMore := list.Len() > page.Size
+	if hasMore {
+		list.Set(list.Slice(0, page.Size))
+	}
+	if cursor.Backward {
+		for i, j := 0, list.Len()-1; i < j; i, j = i+1, j-1 {
+			tmp := reflect.ValueOf(list.Index(i).Interface())
+			list.Index(i).Set(list.Index(j))
+			list.Index(j).Set(tmp)
+		}
+	}
+
+	// generate cursors
+	res := &PageResult{}
+	if 0 == list.Len() {
+		return res, nil
+	}
+	if hasMore || cursor.Backward {
+		res.Next, err = encodePageCursor(list.Index(list.Len()-1).Interface(), fields, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if (hasMore && cursor.Backward) || (page.Cursor != "" && !cursor.Backward) {
+		res.Prev, err = encodePageCursor(list.Index(0).Interface(), fields, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func encodePageCursor(record interface{}, fields []string, backward bool) (string, error) {
+	values, err := readStructValues(record)
+	if err != nil {
+		return "", err
+	}
+
+	c := pageCursor{
+		Backward: backward,
+		Values:   make([]interface{}, 0, len(fields)),
+	}
+	for _, f := range fields {
+		v := values[f]
+		switch nv := v.(type) {
+		case sql.NullString:
+			v, _ = nv.Value()
+		case sql.NullInt64:
+			v, _ = nv.Value()
+		case sql.NullFloat64:
+			v, _ = nv.Value()
+		case sql.NullBool:
+			v, _ = nv.Value()
+		case sql.NullTime:
+			v, _ = nv.Value()
+		case mysql.NullTime:
+			v, _ = nv.Value()
+		}
+		if nil == v {
+			return "", fmt.Errorf("NULL value of field '%s' is not supported in pagination", f)
+		}
+		c.Values = append(c.Values, v)
+	}
+
+	b, err := json.Marshal(&c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodePageCursor(s string, prototype interface{}, fields []string) (c pageCursor, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		err = fmt.Errorf("invalid cursor: %w", err)
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(&c); err != nil {
+		err = fmt.Errorf("invalid cursor: %w", err)
+		return
+	}
+	if len(c.Values) != len(fields) {
+		err = fmt.Errorf("invalid cursor: %d value(s) expected but got %d", len(fields), len(c.Values))
+		return
+	}
+
+	// convert values by types in prototype
+	zeros, err := readStructValues(prototype)
+	if err != nil {
+		return
+	}
+	for i, f := range fields {
+		c.Values[i], err = convCursorValue(c.Values[i], zeros[f])
+		if err != nil {
+			err = fmt.Errorf("invalid cursor value of field '%s': %w", f, err)
+			return
+		}
+	}
+	return
+}
+
+func convCursorValue(v interface{}, zero interface{}) (interface{}, error) {
+	num, isNum := v.(json.Number)
+	str, isStr := v.(string)
+	bl, isBool := v.(bool)
+
+	switch zero.(type) {
+	case sql.NullInt64:
+		if isNum {
+			return num.Int64()
+		}
+	case sql.NullFloat64:
+		if isNum {
+			return num.Float64()
+		}
+	case sql.NullBool:
+		if isBool {
+			return bl, nil
+		}
+	case sql.NullString:
+		if isStr {
+			return str, nil
+		}
+	case time.Time, sql.NullTime, mysql.NullTime:
+		if isStr {
+			return time.Parse(time.RFC3339Nano, str)
+		}
+	default:
+		// basic and named types, such as ID defined as int64
+		switch reflect.ValueOf(zero).Kind() {
+		default:
+			return nil, fmt.Errorf("unsupported type %T", zero)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if isNum {
+				return num.Int64()
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if isNum {
+				n, err := strconv.ParseUint(num.String(), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid unsigned value %v", num)
+				}
+				return n, nil
+			}
+		case reflect.Float32, reflect.Float64:
+			if isNum {
+				return num.Float64()
+			}
+		case reflect.Bool:
+			if isBool {
+				return bl, nil
+			}
+		case reflect.String:
+			if isStr {
+				return str, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected value %v for type %T", v, zero)
+}
+
+// rowCond is a row comparison condition, such as "(`a`, `b`) > (1, 2)"
+type rowCond struct {
+	Params   []string
+	Operator string
+	Values   []interface{}
+}
+
+func (r *rowCond) pack(fieldMap map[string]*Field, b *binder) string {
+	fields := make([]string, 0, len(r.Params))
+	values := make([]string, 0, len(r.Values))
+	for i, p := range r.Params {
+		c := Cond{Param: p, Operator: "=", Value: r.Values[i]}
+		_, _, v, err := c.parse(fieldMap, b)
+		if err != nil {
+			b.fail(err)
+			return ""
+		}
+		fields = append(fields, quoteField(p))
+		values = append(values, v)
+	}
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(fields, ", "), r.Operator, strings.Join(values, ", "))
+}
@@ -0,0 +1,242 @@
+package mysqlx
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPaginate(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	d.MustCreateTable(&txTestRecord{})
+	_, err = d.InsertMany([]txTestRecord{
+		{String: "paginate"}, {String: "paginate"}, {String: "paginate"}, {String: "paginate"}, {String: "paginate"},
+	})
+	if err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+
+	total, err := d.Count(txTestRecord{}, Condition("f_string", "=", "paginate"))
+	if err != nil {
+		t.Errorf("Count error: %v", err)
+		return
+	}
+
+	// walk forward through all pages
+	page := Pagination{
+		Keys: []Order{{Param: "f_id", Seq: "DESC"}},
+		Size: 2,
+	}
+	var firstPage []*txTestRecord
+	var walked int64
+	var lastID int64
+	var pages []string
+	for {
+		var list []*txTestRecord
+		res, err := d.Paginate(&list, page, Condition("f_string", "=", "paginate"))
+		if err != nil {
+			t.Errorf("Paginate error: %v", err)
+			return
+		}
+		if firstPage == nil {
+			firstPage = list
+		}
+		for _, r := range list {
+			if lastID > 0 && r.ID >= lastID {
+				t.Errorf("records not in descending order: %d after %d", r.ID, lastID)
+				return
+			}
+			lastID = r.ID
+		}
+		walked += int64(len(list))
+		pages = append(pages, page.Cursor)
+		if res.Next == "" {
+			break
+		}
+		page.Cursor = res.Next
+	}
+	if walked != total {
+		t.Errorf("%d records expected, but walked %d", total, walked)
+		return
+	}
+	t.Logf("walked %d records in %d pages", walked, len(pages))
+
+	// go back from the second page to the first one
+	if len(pages) < 2 {
+		return
+	}
+	var second []*txTestRecord
+	page.Cursor = pages[1]
+	res, err := d.Paginate(&second, page, Condition("f_string", "=", "paginate"))
+	if err != nil {
+		t.Errorf("Paginate error: %v", err)
+		return
+	}
+	if res.Prev == "" {
+		t.Errorf("previous cursor expected")
+		return
+	}
+
+	var first []*txTestRecord
+	page.Cursor = res.Prev
+	res, err = d.Paginate(&first, page, Condition("f_string", "=", "paginate"))
+	if err != nil {
+		t.Errorf("Paginate error: %v", err)
+		return
+	}
+	if len(first) != len(firstPage) || first[0].ID != firstPage[0].ID {
+		t.Errorf("unexpected previous page: %+v", first)
+		return
+	}
+	if res.Prev != "" {
+		t.Errorf("no previous cursor expected in the first page")
+		return
+	}
+
+	// Order, Limit and Offset are not allowed
+	_, err = d.Paginate(&first, page, Limit(1))
+	if err == nil {
+		t.Errorf("error expected with Limit")
+		return
+	}
+}
+
+type timeKeyRecord struct {
+	ID      int64     `db:"f_id"          mysqlx:"increment:true"`
+	Created time.Time `db:"f_created"     mysqlx:"type:datetime(3)"`
+}
+
+func (timeKeyRecord) Options() Options {
+	return Options{
+		TableName: "t_mysqlx_time_key_test",
+	}
+}
+
+func TestPaginateByFractionalTime(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+	d.Sqlx().Exec("DROP TABLE `t_mysqlx_time_key_test`")
+	d.MustCreateTable(timeKeyRecord{})
+
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := make([]timeKeyRecord, 0, 5)
+	for i := 0; i < 5; i++ {
+		records = append(records, timeKeyRecord{Created: base.Add(time.Duration(i*123) * time.Millisecond)})
+	}
+	if _, err = d.InsertMany(records); err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+
+	// cursor value should keep fractional seconds
+	cursor, err := encodePageCursor(timeKeyRecord{Created: base.Add(123 * time.Millisecond)}, []string{"f_created"}, false)
+	if err != nil {
+		t.Errorf("encodePageCursor error: %v", err)
+		return
+	}
+	_, err = d.Paginate(&[]timeKeyRecord{}, Pagination{
+		Keys:   []Order{{Param: "f_created", Seq: "ASC"}},
+		Cursor: cursor,
+		Size:   2,
+	}, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "SELECT `f_id`, `f_created` FROM `t_mysqlx_time_key_test` "+
+		"WHERE (`f_created`) > ('2020-01-01 00:00:00.123') ORDER BY `f_created` ASC LIMIT 3" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	page := Pagination{
+		Keys: []Order{{Param: "f_created", Seq: "ASC"}},
+		Size: 2,
+	}
+	walked := 0
+	for {
+		var list []timeKeyRecord
+		res, err := d.Paginate(&list, page)
+		if err != nil {
+			t.Errorf("Paginate error: %v", err)
+			return
+		}
+		walked += len(list)
+		if res.Next == "" {
+			break
+		}
+		page.Cursor = res.Next
+	}
+	if walked != len(records) {
+		t.Errorf("%d records expected, but walked %d", len(records), walked)
+		return
+	}
+}
+
+type uintKeyRecord struct {
+	ID     uint64 `db:"f_id"          mysqlx:"increment:true"`
+	String string `db:"f_string"      mysqlx:"type:varchar(128)"`
+}
+
+func (uintKeyRecord) Options() Options {
+	return Options{
+		TableName: "t_mysqlx_uint_key_test",
+	}
+}
+
+type namedCode string
+
+func TestPaginateCursorValues(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	// unsigned keys above math.MaxInt64
+	cursor, err := encodePageCursor(uintKeyRecord{ID: math.MaxUint64 - 1}, []string{"f_id"}, false)
+	if err != nil {
+		t.Errorf("encodePageCursor error: %v", err)
+		return
+	}
+	page := Pagination{
+		Keys:   []Order{{Param: "f_id", Seq: "ASC"}},
+		Cursor: cursor,
+		Size:   2,
+	}
+	_, err = d.Paginate(&[]uintKeyRecord{}, page, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "SELECT `f_id`, `f_string` FROM `t_mysqlx_uint_key_test` "+
+		"WHERE (`f_id`) > (18446744073709551614) ORDER BY `f_id` ASC LIMIT 3" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	// values of named types
+	v, err := convCursorValue(json.Number("3"), namedID(0))
+	if err != nil || v != int64(3) {
+		t.Errorf("unexpected value %v, error: %v", v, err)
+		return
+	}
+	v, err = convCursorValue("abc", namedCode(""))
+	if err != nil || v != "abc" {
+		t.Errorf("unexpected value %v, error: %v", v, err)
+		return
+	}
+
+	// keys should be selected
+	_, err = d.Paginate(&[]uintKeyRecord{}, page, Columns("f_string"), Options{DoNotExec: true})
+	if err == nil || GetQueryFromError(err) != "" {
+		t.Errorf("error expected for unselected keys, but got: %v", err)
+		return
+	}
+}
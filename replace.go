@@ -0,0 +1,80 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// insertMode identifies the statement of Insert and InsertMany functions
+type insertMode int
+
+const (
+	insertModeInsert insertMode = iota
+	insertModeIgnore
+	insertModeReplace
+)
+
+// packInsertVerb returns the statement before table name, such as "INSERT LOW_PRIORITY IGNORE INTO"
+func packInsertVerb(mode insertMode, priority Priority) (string, error) {
+	switch priority {
+	case "", LowPriority:
+		// OK
+	case HighPriority:
+		if mode == insertModeReplace {
+			return "", fmt.Errorf("%s is not allowed in REPLACE statement", priority)
+		}
+	default:
+		return "", fmt.Errorf("invalid priority '%s'", priority)
+	}
+
+	switch mode {
+	default:
+		return joinClauses("INSERT", string(priority), "INTO"), nil
+	case insertModeIgnore:
+		return joinClauses("INSERT", string(priority), "IGNORE INTO"), nil
+	case insertModeReplace:
+		return joinClauses("REPLACE", string(priority), "INTO"), nil
+	}
+}
+
+// InsertIgnore is the same as Insert, but executes 'INSERT IGNORE' statement. Duplicated records are ignored.
+func (d *xdb) InsertIgnore(v interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertWithMode(context.Background(), d.db, insertModeIgnore, v, opts...)
+}
+
+// InsertIgnoreContext is the same as InsertIgnore, with a context.
+func (d *xdb) InsertIgnoreContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertWithMode(ctx, d.db, insertModeIgnore, v, opts...)
+}
+
+// InsertManyIgnore is the same as InsertMany, but executes 'INSERT IGNORE' statement.
+func (d *xdb) InsertManyIgnore(records interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertManyWithMode(context.Background(), d.db, insertModeIgnore, records, opts...)
+}
+
+// InsertManyIgnoreContext is the same as InsertManyIgnore, with a context.
+func (d *xdb) InsertManyIgnoreContext(ctx context.Context, records interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertManyWithMode(ctx, d.db, insertModeIgnore, records, opts...)
+}
+
+// Replace is the same as Insert, but executes 'REPLACE' statement. Duplicated records are deleted before
+// inserting.
+func (d *xdb) Replace(v interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertWithMode(context.Background(), d.db, insertModeReplace, v, opts...)
+}
+
+// ReplaceContext is the same as Replace, with a context.
+func (d *xdb) ReplaceContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertWithMode(ctx, d.db, insertModeReplace, v, opts...)
+}
+
+// ReplaceMany is the same as InsertMany, but executes 'REPLACE' statement.
+func (d *xdb) ReplaceMany(records interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertManyWithMode(context.Background(), d.db, insertModeReplace, records, opts...)
+}
+
+// ReplaceManyContext is the same as ReplaceMany, with a context.
+func (d *xdb) ReplaceManyContext(ctx context.Context, records interface{}, opts ...Options) (sql.Result, error) {
+	return d.insertManyWithMode(ctx, d.db, insertModeReplace, records, opts...)
+}
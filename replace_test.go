@@ -0,0 +1,85 @@
+package mysqlx
+
+import (
+	"testing"
+)
+
+func TestReplaceAndInsertIgnore(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	r := txTestRecord{ID: 1, String: "replace"}
+	records := []*txTestRecord{{String: "a"}, {String: "b"}}
+
+	// statements
+	_, err = d.Replace(&r, Options{DoNotExec: true, Priority: LowPriority})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "REPLACE LOW_PRIORITY INTO `t_mysqlx_tx_test` (`f_id`, `f_string`) VALUES (1, 'replace')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = d.Replace(&r, Options{DoNotExec: true, Priority: HighPriority})
+	if err == nil || GetQueryFromError(err) != "" {
+		t.Errorf("HIGH_PRIORITY should not be allowed in REPLACE")
+		return
+	}
+
+	_, err = d.InsertIgnore(&r, Options{DoNotExec: true, Priority: HighPriority})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "INSERT HIGH_PRIORITY IGNORE INTO `t_mysqlx_tx_test` (`f_id`, `f_string`) VALUES (1, 'replace')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = d.InsertManyIgnore(records, Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "INSERT IGNORE INTO `t_mysqlx_tx_test` (`f_string`) VALUES\n('a'),\n('b')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = d.ReplaceMany(records, Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "REPLACE INTO `t_mysqlx_tx_test` (`f_string`) VALUES\n('a'),\n('b')" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	// execution
+	d.MustCreateTable(&r)
+	res, err := d.Insert(&txTestRecord{String: "replace"})
+	if err != nil {
+		t.Errorf("Insert error: %v", err)
+		return
+	}
+	r.ID, _ = res.LastInsertId()
+
+	res, err = d.InsertIgnore(&r)
+	if err != nil {
+		t.Errorf("InsertIgnore error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 0 {
+		t.Errorf("duplicated record should be ignored, but %d row(s) affected", n)
+		return
+	}
+
+	r.String = "replaced"
+	res, err = d.Replace(&r)
+	if err != nil {
+		t.Errorf("Replace error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 2 {
+		t.Errorf("two rows should be affected in REPLACE, but got %d", n)
+		return
+	}
+}
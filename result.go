@@ -0,0 +1,31 @@
+package mysqlx
+
+import "database/sql"
+
+// multiResult combines results of multiple statements. RowsAffected is the sum of all statements, and
+// LastInsertId is the one of the first statement which inserted any rows.
+type multiResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r *multiResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r *multiResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+// add adds a statement result
+func (r *multiResult) add(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 && r.lastInsertID == 0 {
+		r.lastInsertID, _ = res.LastInsertId()
+	}
+	r.rowsAffected += n
+	return nil
+}
@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -55,6 +56,26 @@ func (d *xdb) getFieldMap(prototype interface{}) (fieldMap map[string]*Field, er
 	return
 }
 
+// getStructPrototype returns Xxx from a Xxx or *Xxx value
+func getStructPrototype(prototype interface{}) (interface{}, error) {
+	if nil == prototype {
+		return nil, fmt.Errorf("nil prototype")
+	}
+	ty := reflect.TypeOf(prototype)
+	va := reflect.ValueOf(prototype)
+	if reflect.Ptr == ty.Kind() {
+		if va.IsNil() {
+			return nil, fmt.Errorf("nil prototype (%v)", ty)
+		}
+		prototype = va.Elem().Interface()
+		ty = ty.Elem()
+	}
+	if reflect.Struct != ty.Kind() {
+		return nil, fmt.Errorf("parameter type invalid (%v)", ty)
+	}
+	return prototype, nil
+}
+
 func (d *xdb) getIncrementField(prototype interface{}) (field *Field, err error) {
 	intfName := reflect.TypeOf(prototype)
 	if fieldValue, exist := d.bufferedIncrField.Load(intfName); exist {
@@ -80,25 +101,107 @@ func (d *xdb) getIncrementField(prototype interface{}) (field *Field, err error)
 
 // Select execute a SQL select statement
 func (d *xdb) Select(dst interface{}, args ...interface{}) error {
-	return d.selectFunc(d.db, dst, args...)
+	return d.selectFunc(context.Background(), d.db, dst, args...)
+}
+
+// SelectContext is the same as Select, with a context.
+func (d *xdb) SelectContext(ctx context.Context, dst interface{}, args ...interface{}) error {
+	return d.selectFunc(ctx, d.db, dst, args...)
 }
 
-func (d *xdb) selectFunc(obj sqlObj, dst interface{}, args ...interface{}) error {
+func (d *xdb) selectFunc(ctx context.Context, obj sqlObj, dst interface{}, args ...interface{}) error {
+	ty, err := getSliceElemStructType(dst)
+	if err != nil {
+		return err
+	}
+
+	query, queryArgs, opt, err := d.packSelect(ctx, obj, ty, args)
+	if err != nil {
+		return err
+	}
+	// log.Println("select query:", query)
+	if opt.DoNotExec {
+		return newErrorWithArgs(doNotExec, query, queryArgs)
+	}
+
+	err = obj.SelectContext(ctx, dst, query, queryArgs...)
+	if err != nil {
+		err = newErrorWithArgs(err.Error(), query, queryArgs)
+		return err
+	}
+	return err
+}
+
+// packSelect packs SELECT statement for given structure type Xxx. obj is used to check whether locking reads
+// are executed in a transaction.
+func (d *xdb) packSelect(
+	ctx context.Context, obj sqlObj, ty reflect.Type, args []interface{},
+) (query string, queryArgs []interface{}, opt Options, err error) {
+	if hasJoin(args) {
+		return d.packJoinSelect(ctx, obj, ty, args)
+	}
+
+	// Should be Xxx
+	prototype := reflect.New(ty).Elem().Interface()
+	fieldsStr, err := d.SelectFields(prototype)
+	if err != nil {
+		// log.Printf("read fields failed: %v", err)
+		return
+	}
+
+	// parse arguments
+	parsedArgs, err := d.handleArgs(prototype, args)
+	if err != nil {
+		return
+	}
+	if err = checkLockingRead(obj, parsedArgs); err != nil {
+		return
+	}
+	if parsedArgs.straightJoin {
+		err = fmt.Errorf("STRAIGHT_JOIN is only supported in JOIN")
+		return
+	}
+	for _, h := range parsedArgs.IndexHints {
+		if h.Table != "" && h.Table != parsedArgs.Opt.TableName {
+			err = fmt.Errorf("table '%s' in index hint not found", h.Table)
+			return
+		}
+	}
+	if !parsedArgs.Opt.DoNotExec {
+		if err = d.checkIndexHints(ctx, parsedArgs.Opt.TableName, parsedArgs.IndexHints); err != nil {
+			return
+		}
+	}
+	if len(parsedArgs.Columns) > 0 {
+		fieldsStr, err = ColumnsType(parsedArgs.Columns).pack(parsedArgs.FieldMap)
+		if err != nil {
+			return
+		}
+	}
+
+	// pack SELECT statements
+	query, queryArgs = packSelectQuery(fieldsStr, parsedArgs)
+	return query, queryArgs, parsedArgs.Opt, nil
+}
+
+// getSliceElemStructType returns type Xxx from *[]Xxx or *[]*Xxx
+func getSliceElemStructType(dst interface{}) (reflect.Type, error) {
+	if nil == dst {
+		return nil, fmt.Errorf("nil destination")
+	}
 
 	// Should be *[]Xxx or *[]*Xxx
 	ty := reflect.TypeOf(dst)
-	va := reflect.ValueOf(dst)
 	// log.Printf("%v - %v\n", ty, ty.Kind())
 	if reflect.Ptr != ty.Kind() {
-		return fmt.Errorf("parameter type invalid (%v)", ty)
+		return nil, fmt.Errorf("parameter type invalid (%v)", ty)
 	}
 
 	// Should be []Xxx or []*Xxx
-	va = va.Elem()
-	ty = va.Type()
+	ty = ty.Elem()
 	// log.Printf("%v - %v\n", ty, ty.Kind())
 	if reflect.Slice != ty.Kind() {
-		return fmt.Errorf("first parameter type invalid (%v)", ty)
+		return nil, fmt.Errorf("first parameter type invalid (%v)", ty)
 	}
 
 	// Should be Xxx or *Xxx
@@ -108,25 +211,37 @@ func (d *xdb) selectFunc(obj sqlObj, dst interface{}, args ...interface{}) error
 		ty = ty.Elem()
 		// log.Printf("%v - %v\n", ty, ty.Kind())
 	}
+	if reflect.Struct != ty.Kind() {
+		return nil, fmt.Errorf("slice element type invalid (%v)", ty)
+	}
+	return ty, nil
+}
 
-	// Should be Xxx
-	prototype := reflect.New(ty).Elem().Interface()
-	fieldsStr, err := d.SelectFields(prototype)
-	if err != nil {
-		// log.Printf("read fields failed: %v", err)
-		return err
+// packSelectQuery packs a SELECT statement with given selected fields and parsed arguments. Arguments for
+// placeholders are returned in the order of their appearance in the statement.
+func packSelectQuery(fieldsStr string, parsedArgs *_parsedArgs) (query string, args []interface{}) {
+	var condStr string
+	if len(parsedArgs.CondList) > 0 {
+		condStr = "WHERE " + strings.Join(parsedArgs.CondList, " AND ")
 	}
 
-	// parse arguments
-	parsedArgs, err := d.handleArgs(prototype, args)
-	if err != nil {
-		return err
+	var groupStr string
+	if len(parsedArgs.GroupList) > 0 {
+		groupFields := make([]string, 0, len(parsedArgs.GroupList))
+		for _, f := range parsedArgs.GroupList {
+			groupFields = append(groupFields, quoteField(f))
+		}
+		groupStr = "GROUP BY " + strings.Join(groupFields, ", ")
 	}
 
-	// pack SELECT statements
-	var offsetStr string
-	if parsedArgs.Offset > 0 {
-		offsetStr = fmt.Sprintf("OFFSET %d", parsedArgs.Offset)
+	var havingStr string
+	if len(parsedArgs.HavingList) > 0 {
+		havingStr = "HAVING " + strings.Join(parsedArgs.HavingList, " AND ")
+	}
+
+	var orderStr string
+	if len(parsedArgs.OrderList) > 0 {
+		orderStr = "ORDER BY " + strings.Join(parsedArgs.OrderList, ", ")
 	}
 
 	var limitStr string
@@ -134,34 +249,42 @@ func (d *xdb) selectFunc(obj sqlObj, dst interface{}, args ...interface{}) error
 		limitStr = fmt.Sprintf("LIMIT %d", parsedArgs.Limit)
 	}
 
-	var orderStr string
-	if len(parsedArgs.OrderList) > 0 {
-		orderStr = "ORDER BY " + strings.Join(parsedArgs.OrderList, ", ")
+	var offsetStr string
+	if parsedArgs.Offset > 0 {
+		offsetStr = fmt.Sprintf("OFFSET %d", parsedArgs.Offset)
 	}
 
-	var condStr string
-	if len(parsedArgs.CondList) > 0 {
-		condStr = "WHERE " + strings.Join(parsedArgs.CondList, " AND ")
+	fromStr := parsedArgs.from
+	if fromStr == "" {
+		// index hints are already checked in parseArgs
+		indexHints, _ := packIndexHints(parsedArgs.IndexHints)
+		fromStr = joinClauses("`"+parsedArgs.Opt.TableName+"`", indexHints)
 	}
 
-	var forUpdateStr string
-	if parsedArgs.forUpdate {
-		forUpdateStr = "FOR UPDATE"
+	var straightJoinStr string
+	if parsedArgs.straightJoin {
+		straightJoinStr = "STRAIGHT_JOIN"
 	}
 
-	query := fmt.Sprintf(
-		"SELECT %s FROM `%s` %s %s %s %s %s",
-		fieldsStr, parsedArgs.Opt.TableName, condStr, orderStr, limitStr, offsetStr, forUpdateStr,
+	query = joinClauses(
+		"SELECT", packOptimizerHints(parsedArgs.OptimizerHints), straightJoinStr, fieldsStr, "FROM", fromStr,
+		condStr, groupStr, havingStr, orderStr, limitStr, offsetStr, parsedArgs.lock,
 	)
-	// log.Println("select query:", query)
-	if parsedArgs.Opt.DoNotExec {
-		return newError(doNotExec, query)
-	}
 
-	err = obj.Select(dst, query)
-	if err != nil {
-		err = newError(err.Error(), query)
-		return err
+	args = make([]interface{}, 0, len(parsedArgs.CondArgs)+len(parsedArgs.HavingArgs)+len(parsedArgs.OrderArgs))
+	args = append(args, parsedArgs.CondArgs...)
+	args = append(args, parsedArgs.HavingArgs...)
+	args = append(args, parsedArgs.OrderArgs...)
+	return
+}
+
+// joinClauses joins non-empty clauses of a statement with spaces
+func joinClauses(clauses ...string) string {
+	parts := make([]string, 0, len(clauses))
+	for _, c := range clauses {
+		if c != "" {
+			parts = append(parts, c)
+		}
 	}
-	return err
+	return strings.Join(parts, " ")
 }
@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -9,15 +10,23 @@ import (
 
 // ========
 
-// SelectOrInsert executes update-if-not-exists statement
+// SelectOrInsert executes update-if-not-exists statement. Concurrent invocations may insert duplicated records if
+// there is no unique key covering the conditions. Please use GetOrInsert instead for a race-free version.
 func (d *xdb) SelectOrInsert(
 	insert interface{}, selectResult interface{}, conds ...interface{},
 ) (res sql.Result, err error) {
-	return d.selectOrInsert(d.db, insert, selectResult, conds...)
+	return d.selectOrInsert(context.Background(), d.db, insert, selectResult, conds...)
+}
+
+// SelectOrInsertContext is the same as SelectOrInsert, with a context.
+func (d *xdb) SelectOrInsertContext(
+	ctx context.Context, insert interface{}, selectResult interface{}, conds ...interface{},
+) (res sql.Result, err error) {
+	return d.selectOrInsert(ctx, d.db, insert, selectResult, conds...)
 }
 
 func (d *xdb) selectOrInsert(
-	obj sqlObj, insert interface{}, selectResult interface{}, conds ...interface{},
+	ctx context.Context, obj sqlObj, insert interface{}, selectResult interface{}, conds ...interface{},
 ) (res sql.Result, err error) {
 
 	// Should be Xxx or *Xxx
@@ -42,14 +51,9 @@ func (d *xdb) selectOrInsert(
 		return nil, fmt.Errorf("select conditions not given")
 	}
 
-	// should have increment field
-	incrField, err := d.getIncrementField(insert)
-	if err != nil {
-		return
-	}
-
 	// handle insert fields and values
-	keys, values, err := d.insertFields(insert, false, false)
+	b := d.newBinder()
+	keys, values, err := d.insertFields(insert, false, false, b)
 	if err != nil {
 		return nil, err
 	}
@@ -73,30 +77,26 @@ func (d *xdb) selectOrInsert(
 		secondList[i] = fmt.Sprintf("%s AS %s", v, addQuoteToString(k, "'"))
 	}
 
-	var randomField string
-	for k := range parsedArgs.FieldMap {
-		randomField = k
-		break
-	}
 	query := fmt.Sprintf(
-		"INSERT INTO `%s` (%s) SELECT * FROM (SELECT %s) AS tmp WHERE NOT EXISTS (SELECT `%s` FROM `%s` WHERE %s) LIMIT 1",
-		parsedArgs.Opt.TableName, strings.Join(firstList, ", "), strings.Join(secondList, ", "), randomField, parsedArgs.Opt.TableName, strings.Join(thirdList, " AND "),
+		"INSERT INTO `%s` (%s) SELECT * FROM (SELECT %s) AS tmp WHERE NOT EXISTS (SELECT 1 FROM `%s` WHERE %s) LIMIT 1",
+		parsedArgs.Opt.TableName, strings.Join(firstList, ", "), strings.Join(secondList, ", "), parsedArgs.Opt.TableName, strings.Join(thirdList, " AND "),
 	)
+	queryArgs := append(b.args, parsedArgs.CondArgs...)
 	// log.Println(query)
 	if parsedArgs.Opt.DoNotExec {
-		return nil, newError(doNotExec, query)
+		return nil, newErrorWithArgs(doNotExec, query, queryArgs)
 	}
 
 	// check table
-	err = d.checkAutoCreateTable(insert, parsedArgs.Opt)
+	err = d.checkAutoCreateTable(ctx, insert, parsedArgs.Opt)
 	if err != nil {
 		return nil, err
 	}
 
 	// exec first
-	res, err = obj.Exec(query)
+	res, err = obj.ExecContext(ctx, query, queryArgs...)
 	if err != nil {
-		return nil, newError(err.Error(), query)
+		return nil, newErrorWithArgs(err.Error(), query, queryArgs)
 	}
 
 	if nil == selectResult {
@@ -104,23 +104,16 @@ func (d *xdb) selectOrInsert(
 		return res, nil
 	}
 
-	// determine insert status
+	// select by conditions, no matter whether the record is inserted or not
 	selectFields, err := d.SelectFields(insert)
 	if err != nil {
 		return res, err
 	}
-	insertID, err := res.LastInsertId()
-	if err != nil {
-		// inserted, now select
-		query = fmt.Sprintf("SELECT %s FROM `%s` WHERE `%s` = %d", selectFields, parsedArgs.Opt.TableName, incrField.Name, insertID)
-
-	} else {
-		// not inserted, just select as above
-		query = fmt.Sprintf("SELECT %s FROM `%s` WHERE %s", selectFields, parsedArgs.Opt.TableName, strings.Join(parsedArgs.CondList, " AND "))
-	}
+	query = fmt.Sprintf("SELECT %s FROM `%s` WHERE %s", selectFields, parsedArgs.Opt.TableName, strings.Join(parsedArgs.CondList, " AND "))
+	queryArgs = parsedArgs.CondArgs
 
 	// log.Println(query)
-	return res, obj.Select(selectResult, query)
+	return res, obj.SelectContext(ctx, selectResult, query, queryArgs...)
 }
 
 // ========
@@ -129,3 +122,10 @@ func (d *xdb) selectOrInsert(
 func (d *xdb) InsertIfNotExists(insert interface{}, conds ...interface{}) (res sql.Result, err error) {
 	return d.SelectOrInsert(insert, nil, conds...)
 }
+
+// InsertIfNotExistsContext is the same as InsertIfNotExists, with a context.
+func (d *xdb) InsertIfNotExistsContext(
+	ctx context.Context, insert interface{}, conds ...interface{},
+) (res sql.Result, err error) {
+	return d.SelectOrInsertContext(ctx, insert, nil, conds...)
+}
@@ -0,0 +1,70 @@
+package mysqlx
+
+// This file handles soft delete. A soft delete field is tagged by `mysqlx:"softdelete:true"`, which should be a
+// nullable time (sql.NullTime or mysql.NullTime) or a boolean (bool or sql.NullBool). For a time field, NULL
+// means not deleted, and deletion sets it to current time. For a boolean field, FALSE or NULL means not deleted,
+// and deletion sets it to TRUE.
+//
+// If a structure has a soft delete field, Delete executes UPDATE statement instead, and other statements with
+// conditions, such as Select, Count, Update and SelectOrInsert, filter deleted records automatically.
+
+// UnscopedType is returned by Unscoped()
+type UnscopedType struct{}
+
+// Unscoped disables the automatic "not deleted" condition of soft delete. With Unscoped, Select and Count could
+// read soft deleted records, and Update could restore them. It does not change the behavior of Delete.
+func Unscoped() *UnscopedType {
+	return &UnscopedType{}
+}
+
+// HardDeleteType is returned by HardDelete()
+type HardDeleteType struct{}
+
+// HardDelete makes Delete execute a real DELETE statement on a structure with soft delete field. Soft deleted
+// records matching the conditions are also deleted. In other statements, it is the same as Unscoped.
+func HardDelete() *HardDeleteType {
+	return &HardDeleteType{}
+}
+
+// softDeleteField returns the soft delete field of given structure, or nil if there is none
+func (d *xdb) softDeleteField(prototype interface{}) *Field {
+	fields, err := d.ReadStructFields(prototype)
+	if err != nil {
+		return nil
+	}
+	for _, f := range fields {
+		if f.SoftDelete {
+			return f
+		}
+	}
+	return nil
+}
+
+// notDeletedCond returns a condition which filters soft deleted records. name is the field name, which may be
+// qualified by a table alias.
+func notDeletedCond(f *Field, name string) string {
+	if f.softDeleteByBool {
+		return quoteField(name) + " IS NOT TRUE"
+	}
+	return quoteField(name) + " IS NULL"
+}
+
+// softDeleteValue returns the value which marks a record as deleted
+func softDeleteValue(f *Field, fieldMap map[string]*Field) (string, error) {
+	if f.softDeleteByBool {
+		return "TRUE", nil
+	}
+	return Now().pack(f.Name, fieldMap, nil, false)
+}
+
+// packSoftDelete packs an UPDATE statement which marks records as deleted
+func packSoftDelete(f *Field, parsedArgs *_parsedArgs, condStr, orderStr, limitStr string) (string, error) {
+	value, err := softDeleteValue(f, parsedArgs.FieldMap)
+	if err != nil {
+		return "", err
+	}
+	return joinClauses(
+		"UPDATE", packOptimizerHints(parsedArgs.OptimizerHints), "`"+parsedArgs.Opt.TableName+"`",
+		"SET", quoteField(f.Name), "=", value, condStr, orderStr, limitStr,
+	), nil
+}
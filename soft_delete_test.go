@@ -0,0 +1,125 @@
+package mysqlx
+
+import (
+	"database/sql"
+	"testing"
+)
+
+type softDeleteRecord struct {
+	ID        int64        `db:"f_id"          mysqlx:"increment:true"`
+	String    string       `db:"f_string"      mysqlx:"type:varchar(128)"`
+	DeletedAt sql.NullTime `db:"f_deleted_at"  mysqlx:"type:datetime(3) softdelete:true"`
+}
+
+func (r *softDeleteRecord) Options() Options {
+	return Options{
+		TableName: "t_mysqlx_soft_delete_test",
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	// statements
+	_, err = d.Delete(&softDeleteRecord{}, Condition("f_string", "=", "a"), Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_soft_delete_test` SET `f_deleted_at` = NOW(3) "+
+		"WHERE `f_string` = 'a' AND `f_deleted_at` IS NULL" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	// Unscoped does not affect soft delete
+	_, err = d.Delete(&softDeleteRecord{}, Condition("f_string", "=", "a"), Unscoped(), Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_soft_delete_test` SET `f_deleted_at` = NOW(3) "+
+		"WHERE `f_string` = 'a' AND `f_deleted_at` IS NULL" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = d.Delete(&softDeleteRecord{}, Condition("f_string", "=", "a"), HardDelete(), Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "DELETE FROM `t_mysqlx_soft_delete_test` WHERE `f_string` = 'a'" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	var records []*softDeleteRecord
+	err = d.Select(&records, Condition("f_string", "=", "a"), Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "SELECT `f_id`, `f_string`, `f_deleted_at` FROM `t_mysqlx_soft_delete_test` "+
+		"WHERE `f_string` = 'a' AND `f_deleted_at` IS NULL" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	err = d.Select(&records, Condition("f_string", "=", "a"), Unscoped(), Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "SELECT `f_id`, `f_string`, `f_deleted_at` FROM `t_mysqlx_soft_delete_test` WHERE `f_string` = 'a'" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	// execution
+	d.MustCreateTable(&softDeleteRecord{})
+	_, err = d.Delete(&softDeleteRecord{}, Condition("f_string", "=", "soft"), HardDelete())
+	if err != nil {
+		t.Errorf("Delete error: %v", err)
+		return
+	}
+	_, err = d.InsertMany([]softDeleteRecord{{String: "soft"}, {String: "soft"}})
+	if err != nil {
+		t.Errorf("InsertMany error: %v", err)
+		return
+	}
+
+	res, err := d.Delete(&softDeleteRecord{}, Condition("f_string", "=", "soft"), Limit(1))
+	if err != nil {
+		t.Errorf("Delete error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Errorf("one row should be soft deleted, but got %d", n)
+		return
+	}
+
+	cnt, err := d.Count(&softDeleteRecord{}, Condition("f_string", "=", "soft"))
+	if err != nil {
+		t.Errorf("Count error: %v", err)
+		return
+	}
+	if cnt != 1 {
+		t.Errorf("one record expected, but got %d", cnt)
+		return
+	}
+
+	cnt, err = d.Count(&softDeleteRecord{}, Condition("f_string", "=", "soft"), Unscoped())
+	if err != nil {
+		t.Errorf("Count error: %v", err)
+		return
+	}
+	if cnt != 2 {
+		t.Errorf("two records expected, but got %d", cnt)
+		return
+	}
+
+	res, err = d.Delete(&softDeleteRecord{}, Condition("f_string", "=", "soft"), HardDelete())
+	if err != nil {
+		t.Errorf("Delete error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 2 {
+		t.Errorf("two rows should be deleted, but got %d", n)
+		return
+	}
+}
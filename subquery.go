@@ -0,0 +1,95 @@
+package mysqlx
+
+import (
+	"fmt"
+)
+
+// SubQueryType is returned by SubQuery()
+type SubQueryType struct {
+	prototype interface{}
+	args      []interface{}
+}
+
+// SubQuery returns a nested SELECT statement, which could be used as the value of a condition with "IN" or
+// "NOT IN" operator, or in Exists() and NotExists(). Arguments are the same as Select. Columns() should be given
+// if the sub-query is used in "IN" conditions. For example:
+//
+//	mysqlx.Condition("id", "in", mysqlx.SubQuery(&Order{}, mysqlx.Columns("user_id"), mysqlx.Condition("amount", ">", 100)))
+func SubQuery(prototype interface{}, args ...interface{}) *SubQueryType {
+	return &SubQueryType{
+		prototype: prototype,
+		args:      args,
+	}
+}
+
+// pack packs sub-query statement with brackets. Arguments are appended into b.
+func (s *SubQueryType) pack(b *binder, exists bool) (string, error) {
+	if nil == s {
+		return "", fmt.Errorf("nil sub-query")
+	}
+	if nil == b || nil == b.db {
+		return "", fmt.Errorf("sub-query is not supported here")
+	}
+
+	prototype, err := getStructPrototype(s.prototype)
+	if err != nil {
+		return "", err
+	}
+	parsedArgs, err := b.db.handleArgs(prototype, s.args)
+	if err != nil {
+		return "", err
+	}
+	if parsedArgs.lock != "" {
+		return "", fmt.Errorf("'%s' is not allowed in sub-query", parsedArgs.lock)
+	}
+
+	fieldsStr := "1"
+	if len(parsedArgs.Columns) > 0 {
+		fieldsStr, err = ColumnsType(parsedArgs.Columns).pack(parsedArgs.FieldMap)
+		if err != nil {
+			return "", err
+		}
+	} else if !exists {
+		return "", fmt.Errorf("columns of sub-query not specified")
+	}
+
+	query, args := packSelectQuery(fieldsStr, parsedArgs)
+	if b.placeholder {
+		b.args = append(b.args, args...)
+	}
+	return "(" + query + ")", nil
+}
+
+// ExistsType is returned by Exists() and NotExists()
+type ExistsType struct {
+	not bool
+	sub *SubQueryType
+}
+
+// Exists returns an EXISTS condition with a sub-query, which is built with the same arguments as Select. It could
+// be used in And, Or or as an argument of CURD functions.
+func Exists(prototype interface{}, args ...interface{}) *ExistsType {
+	return &ExistsType{
+		sub: SubQuery(prototype, args...),
+	}
+}
+
+// NotExists returns a NOT EXISTS condition with a sub-query, just like Exists.
+func NotExists(prototype interface{}, args ...interface{}) *ExistsType {
+	return &ExistsType{
+		not: true,
+		sub: SubQuery(prototype, args...),
+	}
+}
+
+func (e *ExistsType) pack(b *binder) string {
+	s, err := e.sub.pack(b, true)
+	if err != nil {
+		b.fail(fmt.Errorf("EXISTS sub-query error: %w", err))
+		return ""
+	}
+	if e.not {
+		return "NOT EXISTS " + s
+	}
+	return "EXISTS " + s
+}
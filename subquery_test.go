@@ -0,0 +1,52 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubQuery(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	db.MustCreateTable(User{})
+	db.MustCreateTable(joinTestOrder{})
+
+	var list []*User
+	args := []interface{}{
+		Condition("id", "in", SubQuery(joinTestOrder{}, Columns("user_id"), Condition("item", "=", "apple"))),
+		Or{
+			Exists(joinTestOrder{}, Condition("item", "=", "banana")),
+			NotExists(joinTestOrder{}),
+		},
+	}
+	err = db.Select(&list, append(args, Options{DoNotExec: true})...)
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.Contains(query, "`id` IN (SELECT `user_id` FROM `t_mysqlx_join_order` WHERE `item` = 'apple')") {
+		t.Errorf("unexpected IN sub-query statement")
+		return
+	}
+	if !strings.Contains(query, "NOT EXISTS (SELECT 1 FROM `t_mysqlx_join_order`)") {
+		t.Errorf("unexpected NOT EXISTS statement")
+		return
+	}
+
+	err = db.Select(&list, args...)
+	if err != nil {
+		t.Errorf("Select with sub-query error: %v", err)
+		return
+	}
+	t.Logf("got %d user(s)", len(list))
+
+	// columns are required in IN sub-query
+	err = db.Select(&list, Condition("id", "in", SubQuery(joinTestOrder{})))
+	if err == nil {
+		t.Errorf("error expected for sub-query without columns")
+		return
+	}
+	t.Logf("expected error: %v", err)
+}
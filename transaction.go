@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/jmoiron/sqlx"
@@ -24,6 +25,19 @@ func (db *xdb) Begin() (Tx, error) {
 	}, nil
 }
 
+// BeginTx create a transaction with given context and options
+func (db *xdb) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	sqlxTx, err := db.Sqlx().BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx{
+		sqlx: sqlxTx,
+		db:   db,
+	}, nil
+}
+
 func (tx *tx) Sqlx() *sqlx.Tx {
 	return tx.sqlx
 }
@@ -36,37 +50,236 @@ func (tx *tx) Commit() error {
 	return tx.sqlx.Commit()
 }
 
+func (tx *tx) Aggregate(prototype interface{}, dst interface{}, args ...interface{}) error {
+	return tx.db.aggregate(context.Background(), tx.sqlx, prototype, dst, args...)
+}
+
+func (tx *tx) AggregateContext(ctx context.Context, prototype interface{}, dst interface{}, args ...interface{}) error {
+	return tx.db.aggregate(ctx, tx.sqlx, prototype, dst, args...)
+}
+
+func (tx *tx) Count(prototype interface{}, args ...interface{}) (int64, error) {
+	return tx.db.count(context.Background(), tx.sqlx, prototype, args...)
+}
+
+func (tx *tx) CountContext(ctx context.Context, prototype interface{}, args ...interface{}) (int64, error) {
+	return tx.db.count(ctx, tx.sqlx, prototype, args...)
+}
+
 func (tx *tx) Delete(prototype interface{}, args ...interface{}) (sql.Result, error) {
-	return tx.db.delete(tx.sqlx, prototype, args...)
+	return tx.db.delete(context.Background(), tx.sqlx, prototype, args...)
+}
+
+func (tx *tx) DeleteContext(ctx context.Context, prototype interface{}, args ...interface{}) (sql.Result, error) {
+	return tx.db.delete(ctx, tx.sqlx, prototype, args...)
+}
+
+func (tx *tx) DeleteByPK(prototype interface{}, ids ...interface{}) (sql.Result, error) {
+	return tx.db.deleteByPK(context.Background(), tx.sqlx, prototype, ids...)
+}
+
+func (tx *tx) DeleteByPKContext(ctx context.Context, prototype interface{}, ids ...interface{}) (sql.Result, error) {
+	return tx.db.deleteByPK(ctx, tx.sqlx, prototype, ids...)
+}
+
+func (tx *tx) DeleteEntity(v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.deleteEntity(context.Background(), tx.sqlx, v, opts...)
+}
+
+func (tx *tx) DeleteEntityContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.deleteEntity(ctx, tx.sqlx, v, opts...)
+}
+
+func (tx *tx) From(prototype interface{}) *Query {
+	return tx.db.from(tx.sqlx, prototype)
+}
+
+func (tx *tx) Get(dst interface{}, args ...interface{}) error {
+	return tx.db.get(context.Background(), tx.sqlx, dst, args...)
+}
+
+func (tx *tx) GetOrInsert(dst, insert interface{}, conds ...interface{}) (inserted bool, err error) {
+	return tx.db.getOrInsert(context.Background(), tx.sqlx, dst, insert, conds...)
+}
+
+func (tx *tx) GetOrInsertContext(
+	ctx context.Context, dst, insert interface{}, conds ...interface{},
+) (inserted bool, err error) {
+	return tx.db.getOrInsert(ctx, tx.sqlx, dst, insert, conds...)
+}
+
+func (tx *tx) GetContext(ctx context.Context, dst interface{}, args ...interface{}) error {
+	return tx.db.get(ctx, tx.sqlx, dst, args...)
+}
+
+func (tx *tx) Iterate(prototype interface{}, callback func(row interface{}) error, args ...interface{}) error {
+	return tx.db.iterate(context.Background(), tx.sqlx, prototype, callback, args...)
+}
+
+func (tx *tx) IterateContext(
+	ctx context.Context, prototype interface{}, callback func(row interface{}) error, args ...interface{},
+) error {
+	return tx.db.iterate(ctx, tx.sqlx, prototype, callback, args...)
 }
 
 func (tx *tx) Insert(v interface{}, opts ...Options) (sql.Result, error) {
-	return tx.db.insert(tx.sqlx, v, opts...)
+	return tx.db.insert(context.Background(), tx.sqlx, v, opts...)
+}
+
+func (tx *tx) InsertContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insert(ctx, tx.sqlx, v, opts...)
 }
 
 func (tx *tx) InsertIfNotExists(insert interface{}, conds ...interface{}) (sql.Result, error) {
-	return tx.db.selectOrInsert(tx.sqlx, insert, nil, conds...)
+	return tx.db.selectOrInsert(context.Background(), tx.sqlx, insert, nil, conds...)
 }
+
+func (tx *tx) InsertIfNotExistsContext(ctx context.Context, insert interface{}, conds ...interface{}) (sql.Result, error) {
+	return tx.db.selectOrInsert(ctx, tx.sqlx, insert, nil, conds...)
+}
+
 func (tx *tx) InsertMany(records interface{}, opts ...Options) (result sql.Result, err error) {
-	return tx.db.insertMany(tx.sqlx, records, opts...)
+	return tx.db.insertMany(context.Background(), tx.sqlx, records, opts...)
+}
+
+func (tx *tx) InsertManyContext(ctx context.Context, records interface{}, opts ...Options) (result sql.Result, err error) {
+	return tx.db.insertMany(ctx, tx.sqlx, records, opts...)
+}
+
+func (tx *tx) InsertIgnore(v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertWithMode(context.Background(), tx.sqlx, insertModeIgnore, v, opts...)
+}
+
+func (tx *tx) InsertIgnoreContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertWithMode(ctx, tx.sqlx, insertModeIgnore, v, opts...)
+}
+
+func (tx *tx) InsertManyIgnore(records interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertManyWithMode(context.Background(), tx.sqlx, insertModeIgnore, records, opts...)
+}
+
+func (tx *tx) InsertManyIgnoreContext(ctx context.Context, records interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertManyWithMode(ctx, tx.sqlx, insertModeIgnore, records, opts...)
+}
+
+func (tx *tx) Replace(v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertWithMode(context.Background(), tx.sqlx, insertModeReplace, v, opts...)
+}
+
+func (tx *tx) ReplaceContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertWithMode(ctx, tx.sqlx, insertModeReplace, v, opts...)
+}
+
+func (tx *tx) ReplaceMany(records interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertManyWithMode(context.Background(), tx.sqlx, insertModeReplace, records, opts...)
+}
+
+func (tx *tx) ReplaceManyContext(ctx context.Context, records interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.insertManyWithMode(ctx, tx.sqlx, insertModeReplace, records, opts...)
 }
 
 func (tx *tx) InsertOnDuplicateKeyUpdate(v interface{}, updates map[string]interface{}, opts ...Options) (sql.Result, error) {
-	return tx.db.insertOnDuplicateKeyUpdate(tx.sqlx, v, updates, opts...)
+	return tx.db.insertOnDuplicateKeyUpdate(context.Background(), tx.sqlx, v, updates, opts...)
+}
+
+func (tx *tx) InsertOnDuplicateKeyUpdateContext(
+	ctx context.Context, v interface{}, updates map[string]interface{}, opts ...Options,
+) (sql.Result, error) {
+	return tx.db.insertOnDuplicateKeyUpdate(ctx, tx.sqlx, v, updates, opts...)
+}
+
+func (tx *tx) InsertOnDuplicateKeyUpdateResult(
+	v interface{}, updates map[string]interface{}, opts ...Options,
+) (UpsertResult, error) {
+	return tx.db.insertOnDuplicateKeyUpdate(context.Background(), tx.sqlx, v, updates, opts...)
+}
+
+func (tx *tx) InsertOnDuplicateKeyUpdateResultContext(
+	ctx context.Context, v interface{}, updates map[string]interface{}, opts ...Options,
+) (UpsertResult, error) {
+	return tx.db.insertOnDuplicateKeyUpdate(ctx, tx.sqlx, v, updates, opts...)
 }
 
 func (tx *tx) InsertManyOnDuplicateKeyUpdate(records interface{}, updates map[string]interface{}, opts ...Options) (sql.Result, error) {
-	return tx.db.insertManyOnDuplicateKeyUpdate(tx.sqlx, records, updates, opts...)
+	return tx.db.insertManyOnDuplicateKeyUpdate(context.Background(), tx.sqlx, records, updates, opts...)
+}
+
+func (tx *tx) InsertManyOnDuplicateKeyUpdateContext(
+	ctx context.Context, records interface{}, updates map[string]interface{}, opts ...Options,
+) (sql.Result, error) {
+	return tx.db.insertManyOnDuplicateKeyUpdate(ctx, tx.sqlx, records, updates, opts...)
+}
+
+func (tx *tx) InsertManyOnDuplicateKeyUpdateResult(
+	records interface{}, updates map[string]interface{}, opts ...Options,
+) (UpsertResult, error) {
+	return tx.db.insertManyOnDuplicateKeyUpdate(context.Background(), tx.sqlx, records, updates, opts...)
+}
+
+func (tx *tx) InsertManyOnDuplicateKeyUpdateResultContext(
+	ctx context.Context, records interface{}, updates map[string]interface{}, opts ...Options,
+) (UpsertResult, error) {
+	return tx.db.insertManyOnDuplicateKeyUpdate(ctx, tx.sqlx, records, updates, opts...)
+}
+
+func (tx *tx) Paginate(dst interface{}, page Pagination, args ...interface{}) (*PageResult, error) {
+	return tx.db.paginate(context.Background(), tx.sqlx, dst, page, args...)
+}
+
+func (tx *tx) PaginateContext(
+	ctx context.Context, dst interface{}, page Pagination, args ...interface{},
+) (*PageResult, error) {
+	return tx.db.paginate(ctx, tx.sqlx, dst, page, args...)
+}
+
+func (tx *tx) Save(v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.updateStruct(context.Background(), tx.sqlx, v, optionsToArgs(opts)...)
+}
+
+func (tx *tx) SaveContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return tx.db.updateStruct(ctx, tx.sqlx, v, optionsToArgs(opts)...)
 }
 
 func (tx *tx) Select(dst interface{}, args ...interface{}) error {
-	return tx.db.selectFunc(tx.sqlx, dst, args...)
+	return tx.db.selectFunc(context.Background(), tx.sqlx, dst, args...)
+}
+
+func (tx *tx) SelectContext(ctx context.Context, dst interface{}, args ...interface{}) error {
+	return tx.db.selectFunc(ctx, tx.sqlx, dst, args...)
 }
 
 func (tx *tx) SelectOrInsert(insert interface{}, selectResult interface{}, conds ...interface{}) (sql.Result, error) {
-	return tx.db.selectOrInsert(tx.sqlx, insert, selectResult, conds...)
+	return tx.db.selectOrInsert(context.Background(), tx.sqlx, insert, selectResult, conds...)
+}
+
+func (tx *tx) SelectOrInsertContext(
+	ctx context.Context, insert interface{}, selectResult interface{}, conds ...interface{},
+) (sql.Result, error) {
+	return tx.db.selectOrInsert(ctx, tx.sqlx, insert, selectResult, conds...)
 }
 
 func (tx *tx) Update(prototype interface{}, fields map[string]interface{}, args ...interface{}) (sql.Result, error) {
-	return tx.db.update(tx.sqlx, prototype, fields, args...)
+	return tx.db.update(context.Background(), tx.sqlx, prototype, fields, args...)
+}
+
+func (tx *tx) UpdateContext(
+	ctx context.Context, prototype interface{}, fields map[string]interface{}, args ...interface{},
+) (sql.Result, error) {
+	return tx.db.update(ctx, tx.sqlx, prototype, fields, args...)
+}
+
+func (tx *tx) UpdateStruct(v interface{}, args ...interface{}) (sql.Result, error) {
+	return tx.db.updateStruct(context.Background(), tx.sqlx, v, args...)
+}
+
+func (tx *tx) UpdateStructContext(ctx context.Context, v interface{}, args ...interface{}) (sql.Result, error) {
+	return tx.db.updateStruct(ctx, tx.sqlx, v, args...)
+}
+
+func (tx *tx) UpdateChanged(old, new interface{}, conds ...interface{}) (sql.Result, error) {
+	return tx.db.updateChanged(context.Background(), tx.sqlx, old, new, conds...)
+}
+
+func (tx *tx) UpdateChangedContext(ctx context.Context, old, new interface{}, conds ...interface{}) (sql.Result, error) {
+	return tx.db.updateChanged(ctx, tx.sqlx, old, new, conds...)
 }
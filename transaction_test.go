@@ -177,3 +177,51 @@ func testTx02(t *testing.T, d DB, id int64, v string, wg *sync.WaitGroup) {
 	// done
 	return
 }
+
+func TestLockingReads(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+	d.MustCreateTable(&txTestRecord{})
+
+	// statements
+	statements := map[string]*ForUpdateType{
+		"FOR UPDATE":                             ForUpdate(),
+		"FOR SHARE":                              ForShare(),
+		"LOCK IN SHARE MODE":                     LockInShareMode(),
+		"FOR UPDATE NOWAIT":                      ForUpdate().NoWait(),
+		"FOR UPDATE SKIP LOCKED":                 ForUpdate().SkipLocked(),
+		"FOR SHARE OF `t_mysqlx_tx_test` NOWAIT": ForShare().Of("t_mysqlx_tx_test").NoWait(),
+	}
+	tx, err := d.Begin()
+	if err != nil {
+		t.Errorf("Begin error: %v", err)
+		return
+	}
+	defer tx.Rollback()
+
+	for expected, lock := range statements {
+		var list []*txTestRecord
+		err = tx.Select(&list, lock, Limit(1), Options{DoNotExec: true})
+		if !strings.HasSuffix(GetQueryFromError(err), expected) {
+			t.Errorf("'%s' expected, but got '%s'", expected, GetQueryFromError(err))
+			return
+		}
+	}
+
+	// invalid combinations and usage outside transaction
+	var list []*txTestRecord
+	err = tx.Select(&list, LockInShareMode().SkipLocked())
+	if err == nil {
+		t.Errorf("error expected with LOCK IN SHARE MODE SKIP LOCKED")
+		return
+	}
+	err = d.Select(&list, ForUpdate().SkipLocked())
+	if err == nil {
+		t.Errorf("error expected with locking read outside transaction")
+		return
+	}
+	t.Logf("expected error: %v", err)
+}
@@ -1,6 +1,7 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -16,40 +17,225 @@ type Param struct {
 	Pass   string
 	DBName string
 
+	// Params are additional DSN parameters of the driver. Set "clientFoundRows" as "true" to make MySQL count
+	// found rows instead of changed rows, which also affects the outcome of InsertOnDuplicateKeyUpdate.
 	Params map[string]string
+
+	// UsePlaceholder makes mysqlx pack values as '?' placeholders and pass them to the driver as arguments,
+	// instead of writing escaped literals into SQL statements. Binary and non-UTF-8 strings could be stored
+	// as they are in this mode, and the driver would use server-side prepared statements.
+	UsePlaceholder bool
 }
 
 // CURD interface declares supported MySQL CURD operations
 type CURD interface {
 
-	// Insert insert a given structure. auto-increment fields will be ignored.
+	// Aggregate executes a SELECT statement with aggregate functions and GROUP BY statement, and scans results
+	// into dst, which should be a pointer to a slice of structures.
+	Aggregate(prototype interface{}, dst interface{}, args ...interface{}) error
+
+	// AggregateContext is the same as Aggregate, with a context.
+	AggregateContext(ctx context.Context, prototype interface{}, dst interface{}, args ...interface{}) error
+
+	// Count returns the number of records matching given conditions.
+	Count(prototype interface{}, args ...interface{}) (int64, error)
+
+	// CountContext is the same as Count, with a context.
+	CountContext(ctx context.Context, prototype interface{}, args ...interface{}) (int64, error)
+
+	// From starts a chainable query with given table structure prototype, such as
+	// db.From(&User{}).Where("id", ">", 100).OrderBy("id", mysqlx.Desc).Limit(10).Find(&users).
+	From(prototype interface{}) *Query
+
+	// Insert insert a given structure. auto-increment fields will be ignored. If a pointer is given, the generated
+	// auto-increment ID will be written back into the structure.
 	Insert(v interface{}, opts ...Options) (sql.Result, error)
 
+	// InsertContext is the same as Insert, with a context.
+	InsertContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error)
+
 	// InsertIfNotExists is the same as SelectOrInsert but lacking select statement
 	InsertIfNotExists(insert interface{}, conds ...interface{}) (sql.Result, error)
 
+	// InsertIfNotExistsContext is the same as InsertIfNotExists, with a context.
+	InsertIfNotExistsContext(ctx context.Context, insert interface{}, conds ...interface{}) (sql.Result, error)
+
 	// InsertMany insert multiple records into table. If additional option with table name is not given,
-	// mysqlx will use the FIRST table name in records for all.
+	// mysqlx will use the FIRST table name in records for all. Records are split into multiple statements if they
+	// exceed MaxBatchRows or MaxBatchBytes in Options, or @@max_allowed_packet of the server. If a statement fails
+	// and BatchInTx is not set, the result of previous statements is returned together with the error. If records
+	// are given as []*Xxx or *[]*Xxx, generated auto-increment IDs will be written back into them if
+	// @@innodb_autoinc_lock_mode is 0 or 1, or each statement inserts only one record.
 	InsertMany(records interface{}, opts ...Options) (result sql.Result, err error)
 
+	// InsertManyContext is the same as InsertMany, with a context.
+	InsertManyContext(ctx context.Context, records interface{}, opts ...Options) (result sql.Result, err error)
+
+	// InsertIgnore is the same as Insert, but executes 'INSERT IGNORE' statement. Duplicated records are ignored.
+	InsertIgnore(v interface{}, opts ...Options) (sql.Result, error)
+
+	// InsertIgnoreContext is the same as InsertIgnore, with a context.
+	InsertIgnoreContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error)
+
+	// InsertManyIgnore is the same as InsertMany, but executes 'INSERT IGNORE' statement.
+	InsertManyIgnore(records interface{}, opts ...Options) (sql.Result, error)
+
+	// InsertManyIgnoreContext is the same as InsertManyIgnore, with a context.
+	InsertManyIgnoreContext(ctx context.Context, records interface{}, opts ...Options) (sql.Result, error)
+
+	// Replace is the same as Insert, but executes 'REPLACE' statement. Duplicated records are deleted before
+	// inserting.
+	Replace(v interface{}, opts ...Options) (sql.Result, error)
+
+	// ReplaceContext is the same as Replace, with a context.
+	ReplaceContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error)
+
+	// ReplaceMany is the same as InsertMany, but executes 'REPLACE' statement.
+	ReplaceMany(records interface{}, opts ...Options) (sql.Result, error)
+
+	// ReplaceManyContext is the same as ReplaceMany, with a context.
+	ReplaceManyContext(ctx context.Context, records interface{}, opts ...Options) (sql.Result, error)
+
 	// InsertOnDuplicateKeyUpdate executes 'INSERT ... ON DUPLICATE KEY UPDATE ...' statements. This function is
 	// a combination of Insert and Update, without WHERE conditions.
 	InsertOnDuplicateKeyUpdate(v interface{}, updates map[string]interface{}, opts ...Options) (sql.Result, error)
 
+	// InsertOnDuplicateKeyUpdateContext is the same as InsertOnDuplicateKeyUpdate, with a context.
+	InsertOnDuplicateKeyUpdateContext(
+		ctx context.Context, v interface{}, updates map[string]interface{}, opts ...Options,
+	) (sql.Result, error)
+
+	// InsertOnDuplicateKeyUpdateResult is the same as InsertOnDuplicateKeyUpdate, but returns an UpsertResult.
+	// Outcome of the returned result tells whether the record is inserted, updated or unchanged.
+	InsertOnDuplicateKeyUpdateResult(v interface{}, updates map[string]interface{}, opts ...Options) (UpsertResult, error)
+
+	// InsertOnDuplicateKeyUpdateResultContext is the same as InsertOnDuplicateKeyUpdateResult, with a context.
+	InsertOnDuplicateKeyUpdateResultContext(
+		ctx context.Context, v interface{}, updates map[string]interface{}, opts ...Options,
+	) (UpsertResult, error)
+
 	// InsertManyOnDuplicateKeyUpdate is similar with InsertOnDuplicateKeyUpdate, but insert mutiple records for onetime.
+	// Records are split into multiple statements in the same way as InsertMany. Auto-increment values should be
+	// given in all records or none of them.
 	InsertManyOnDuplicateKeyUpdate(records interface{}, updates map[string]interface{}, opts ...Options) (sql.Result, error)
 
+	// InsertManyOnDuplicateKeyUpdateContext is the same as InsertManyOnDuplicateKeyUpdate, with a context.
+	InsertManyOnDuplicateKeyUpdateContext(
+		ctx context.Context, records interface{}, updates map[string]interface{}, opts ...Options,
+	) (sql.Result, error)
+
+	// InsertManyOnDuplicateKeyUpdateResult is the same as InsertManyOnDuplicateKeyUpdate, but returns an
+	// UpsertResult. Counters of each statement could be read by Batches of the returned result.
+	InsertManyOnDuplicateKeyUpdateResult(
+		records interface{}, updates map[string]interface{}, opts ...Options,
+	) (UpsertResult, error)
+
+	// InsertManyOnDuplicateKeyUpdateResultContext is the same as InsertManyOnDuplicateKeyUpdateResult, with a context.
+	InsertManyOnDuplicateKeyUpdateResultContext(
+		ctx context.Context, records interface{}, updates map[string]interface{}, opts ...Options,
+	) (UpsertResult, error)
+
 	// Select execute a SQL select statement
 	Select(dst interface{}, args ...interface{}) error
 
+	// SelectContext is the same as Select, with a context.
+	SelectContext(ctx context.Context, dst interface{}, args ...interface{}) error
+
 	// SelectOrInsert executes update-if-not-exists statement
 	SelectOrInsert(insert interface{}, selectResult interface{}, conds ...interface{}) (sql.Result, error)
 
+	// SelectOrInsertContext is the same as SelectOrInsert, with a context.
+	SelectOrInsertContext(
+		ctx context.Context, insert interface{}, selectResult interface{}, conds ...interface{},
+	) (sql.Result, error)
+
+	// Iterate executes a SQL select statement just like Select, but scans records one by one into a new pointer
+	// to prototype structure, and then invokes callback with it, instead of loading all records into a slice. If
+	// callback returns an error, iteration stops and the error will be returned.
+	Iterate(prototype interface{}, callback func(row interface{}) error, args ...interface{}) error
+
+	// IterateContext is the same as Iterate, with a context.
+	IterateContext(
+		ctx context.Context, prototype interface{}, callback func(row interface{}) error, args ...interface{},
+	) error
+
+	// Get selects the first record matching given arguments into dst, which should be a pointer to a structure.
+	// If no record matches, sql.ErrNoRows will be returned.
+	Get(dst interface{}, args ...interface{}) error
+
+	// GetContext is the same as Get, with a context.
+	GetContext(ctx context.Context, dst interface{}, args ...interface{}) error
+
+	// GetOrInsert selects the first record matching given conditions into dst, or inserts given record by
+	// 'INSERT ... ON DUPLICATE KEY UPDATE' which changes nothing on duplicated keys, and selects it again if none
+	// matches. inserted tells whether the record is inserted. A unique key covering the conditions is required to
+	// make concurrent invocations get the same record. ErrSoftDeleted is returned if the conflicting record is soft
+	// deleted.
+	GetOrInsert(dst, insert interface{}, conds ...interface{}) (inserted bool, err error)
+
+	// GetOrInsertContext is the same as GetOrInsert, with a context.
+	GetOrInsertContext(ctx context.Context, dst, insert interface{}, conds ...interface{}) (inserted bool, err error)
+
+	// Paginate selects one page of records into dst by keyset pagination with given keys and cursor. Other
+	// arguments are the same as Select, except Order, Limit and Offset. Columns should include all keys. Cursors
+	// of next and previous pages are returned in PageResult.
+	Paginate(dst interface{}, page Pagination, args ...interface{}) (*PageResult, error)
+
+	// PaginateContext is the same as Paginate, with a context.
+	PaginateContext(ctx context.Context, dst interface{}, page Pagination, args ...interface{}) (*PageResult, error)
+
 	// Delete executes SQL DELETE statement with given conditions
 	Delete(prototype interface{}, args ...interface{}) (sql.Result, error)
 
-	// Update execute UPDATE SQL statement with given structure and conditions
+	// DeleteContext is the same as Delete, with a context.
+	DeleteContext(ctx context.Context, prototype interface{}, args ...interface{}) (sql.Result, error)
+
+	// Save updates all non-key fields of given structure by its primary key, which is PrimaryKey in Options, or
+	// the auto-increment field if PrimaryKey is not declared. If the structure has a version field, it is checked
+	// and increased, and ErrStaleVersion is returned if no rows are affected.
+	Save(v interface{}, opts ...Options) (sql.Result, error)
+
+	// SaveContext is the same as Save, with a context.
+	SaveContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error)
+
+	// UpdateStruct is the same as Save, but only fields given by Columns arguments are updated if there are any.
+	// Options arguments are also acceptable.
+	UpdateStruct(v interface{}, args ...interface{}) (sql.Result, error)
+
+	// UpdateStructContext is the same as UpdateStruct, with a context.
+	UpdateStructContext(ctx context.Context, v interface{}, args ...interface{}) (sql.Result, error)
+
+	// UpdateChanged compares two values of the same structure, and updates changed fields only. If no conditions
+	// are given, the primary key values of old one will be used. Primary key, auto-increment and version fields are
+	// not compared. If nothing changed, no statement will be executed and a result with zero affected rows is
+	// returned. If the structure has a version field, the version in old one is checked and increased.
+	UpdateChanged(old, new interface{}, conds ...interface{}) (sql.Result, error)
+
+	// UpdateChangedContext is the same as UpdateChanged, with a context.
+	UpdateChangedContext(ctx context.Context, old, new interface{}, conds ...interface{}) (sql.Result, error)
+
+	// DeleteByPK deletes records by values of the primary key, which should be a single field. Values could be
+	// given one by one, or in slices. Large value lists are split into multiple DELETE statements.
+	DeleteByPK(prototype interface{}, ids ...interface{}) (sql.Result, error)
+
+	// DeleteByPKContext is the same as DeleteByPK, with a context.
+	DeleteByPKContext(ctx context.Context, prototype interface{}, ids ...interface{}) (sql.Result, error)
+
+	// DeleteEntity deletes the record of given structure by its primary key values.
+	DeleteEntity(v interface{}, opts ...Options) (sql.Result, error)
+
+	// DeleteEntityContext is the same as DeleteEntity, with a context.
+	DeleteEntityContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error)
+
+	// Update execute UPDATE SQL statement with given structure and conditions. If the structure has a version
+	// field, the version is increased. Non-zero version in prototype is also checked, and ErrStaleVersion is
+	// returned if no rows are affected then.
 	Update(prototype interface{}, fields map[string]interface{}, args ...interface{}) (sql.Result, error)
+
+	// UpdateContext is the same as Update, with a context.
+	UpdateContext(
+		ctx context.Context, prototype interface{}, fields map[string]interface{}, args ...interface{},
+	) (sql.Result, error)
 }
 
 // DB represent a connection
@@ -70,6 +256,10 @@ type DB interface {
 	// Begin start a transaction
 	Begin() (Tx, error)
 
+	// BeginTx starts a transaction with given context and options. The context is used until the transaction is
+	// committed or rolled back. If the context is canceled, the transaction will be rolled back.
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+
 	// CreateOrAlterTableStatements returns 'CREATE TABLE ... IF NOT EXISTS ...' or 'ALTER TABLE ...' statements, but
 	// will not execute them. If the table does not exists, 'CREATE TABLE ...' statement will be returned. If the table
 	// exists and needs no alteration, an empty string slice would be returned. Otherwise, a string slice with 'ALTER
@@ -81,6 +271,9 @@ type DB interface {
 	// CreateTable creates a table if not exist. If the table exists, it will alter it if necessary
 	CreateTable(v interface{}, opts ...Options) error
 
+	// CreateTableContext is the same as CreateTable, with a context.
+	CreateTableContext(ctx context.Context, v interface{}, opts ...Options) error
+
 	// CurrentDatabase gets current operating database
 	CurrentDatabase() (string, error)
 
@@ -111,7 +304,8 @@ type DB interface {
 	// StructFields is the same as ReadStructFields.
 	StructFields(s interface{}) (ret []*Field, err error)
 
-	// ReadStructFields returns all valid SQL fields by given structure and will buffer it.
+	// ReadStructFields returns all valid SQL fields by given structure and will buffer it. Buffered fields are
+	// shared by all callers, please do not modify them.
 	ReadStructFields(s interface{}) (ret []*Field, err error)
 
 	// Sqlx return the *sqlx.DB object.
@@ -179,8 +373,13 @@ type Field struct {
 	Comment       string
 	AutoIncrement bool
 	OnUpdate      string
+	// SoftDelete identifies the soft delete field, which is tagged by "softdelete:true"
+	SoftDelete bool
+	// Version identifies the version field for optimistic locking, which is tagged by "version:true"
+	Version bool
 	// private
-	statement string
+	statement        string
+	softDeleteByBool bool
 }
 
 // Options identifies options and parameters for a structure
@@ -193,6 +392,9 @@ type Options struct {
 	Indexes []Index
 	// Uniques defines the uniques of the table
 	Uniques []Unique
+	// PrimaryKey defines field names of the primary key. If not given, the auto-increment field will be used as
+	// the primary key. It is used in create table statement and functions like Save.
+	PrimaryKey []string
 	// CreateTableParams defines additional variables in create table statements.
 	// There are three default variables, which could be replaced:
 	// ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb4
@@ -201,8 +403,30 @@ type Options struct {
 	// functions would return an Error object with SQL query statement. This could used for troubleshot.
 	// Please use GetQueryFromError() function to get the query statement.
 	DoNotExec bool
+	// MaxBatchRows limits the number of records in one statement of InsertMany functions. Records exceeding the
+	// limit are inserted by multiple statements. Zero means no limit.
+	MaxBatchRows int
+	// MaxBatchBytes limits the size of one statement of InsertMany functions. Zero means using
+	// @@max_allowed_packet of the server, and negative means no limit.
+	MaxBatchBytes int
+	// BatchInTx executes multiple statements of InsertMany functions in one transaction, unless they are already
+	// invoked in a transaction.
+	BatchInTx bool
+	// Priority defines the priority modifier of INSERT and REPLACE statements, such as LowPriority. HighPriority
+	// is not allowed in REPLACE statements.
+	Priority Priority
 }
 
+// Priority is the priority modifier of MySQL INSERT and REPLACE statements
+type Priority string
+
+const (
+	// LowPriority delays the execution until no other clients are reading from the table
+	LowPriority Priority = "LOW_PRIORITY"
+	// HighPriority overrides the effect of --low-priority-updates option of MySQL server
+	HighPriority Priority = "HIGH_PRIORITY"
+)
+
 // Offset is for MySQL offset statement
 type Offset int
 
@@ -210,9 +434,11 @@ type Offset int
 type Limit int
 
 // Raw stores a raw MySQL query statement. In mysqlx operation, Raw type will added to sql query statement directly
-// without any escaping.
+// without any escaping, even if Param.UsePlaceholder is enabled.
 //
 // Currently only update fields supports Raw, like:
 //
 //	map[string]interface{}{"id": mysqlx.Raw("= id")}
+//
+// To use field references or expressions in conditions, please use Col and Expr instead.
 type Raw string
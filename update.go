@@ -1,9 +1,11 @@
 package mysqlx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -11,15 +13,37 @@ import (
 
 // ========
 
-// Update execute UPDATE SQL statement with given structure and conditions
+// Update execute UPDATE SQL statement with given structure and conditions. If the structure has a version field
+// which is not given in fields, the field is increased by one. If the version in prototype is not zero, it is also
+// added as a condition, and ErrStaleVersion is returned if no rows are affected then. Zero versions are not
+// checked, as zero prototypes are used by UpdateT and Query.Update.
 func (d *xdb) Update(
 	prototype interface{}, fields map[string]interface{}, args ...interface{},
 ) (sql.Result, error) {
-	return d.update(d.db, prototype, fields, args...)
+	return d.update(context.Background(), d.db, prototype, fields, args...)
+}
+
+// UpdateContext is the same as Update, with a context.
+func (d *xdb) UpdateContext(
+	ctx context.Context, prototype interface{}, fields map[string]interface{}, args ...interface{},
+) (sql.Result, error) {
+	return d.update(ctx, d.db, prototype, fields, args...)
 }
 
 func (d *xdb) update(
-	obj sqlObj, prototype interface{}, fields map[string]interface{}, args ...interface{},
+	ctx context.Context, obj sqlObj, prototype interface{}, fields map[string]interface{}, args ...interface{},
+) (sql.Result, error) {
+	var record interface{}
+	if d.hasVersion(prototype) {
+		record = prototype
+	}
+	return d.updateWithVersion(ctx, obj, prototype, record, fields, args...)
+}
+
+// updateWithVersion is the same as update. If record is not nil, the value of its version field is checked, and
+// ErrStaleVersion is returned if no rows are affected.
+func (d *xdb) updateWithVersion(
+	ctx context.Context, obj sqlObj, prototype, record interface{}, fields map[string]interface{}, args ...interface{},
 ) (sql.Result, error) {
 	if nil == fields || 0 == len(fields) {
 		return nil, fmt.Errorf("nil fields")
@@ -44,8 +68,20 @@ func (d *xdb) update(
 	var limitStr string
 	var condStr string
 
+	// optimistic locking, unless version is updated explicitly
+	versionField := d.versionField(prototype)
+	if versionField != nil {
+		if _, exist := fields[versionField.Name]; exist {
+			versionField = nil
+		}
+	}
+	if versionField != nil {
+		fields, args = versionUpdateArgs(record, versionField, fields, args)
+	}
+
 	// handle fields
-	kv, err := d.genUpdateKVs(prototype, fields)
+	b := d.newBinder()
+	kv, err := d.genUpdateKVs(prototype, fields, b, false)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +93,11 @@ func (d *xdb) update(
 	if err != nil {
 		return nil, err
 	}
+	if err = checkHintsInModification("UPDATE", parsedArgs); err != nil {
+		return nil, err
+	}
+	// index hints are already checked in parseArgs
+	indexHints, _ := packIndexHints(parsedArgs.IndexHints)
 	if parsedArgs.Limit > 0 {
 		limitStr = "LIMIT " + strconv.Itoa(parsedArgs.Limit)
 	}
@@ -65,34 +106,59 @@ func (d *xdb) update(
 		condStr = "WHERE " + strings.Join(parsedArgs.CondList, " AND ")
 	}
 
-	query := fmt.Sprintf("UPDATE `%s` SET %s %s %s", opt.TableName, strings.Join(kv, ", "), condStr, limitStr)
+	query := joinClauses(
+		"UPDATE", packOptimizerHints(parsedArgs.OptimizerHints), "`"+opt.TableName+"`", indexHints,
+		"SET", strings.Join(kv, ", "), condStr, limitStr,
+	)
+	queryArgs := append(b.args, parsedArgs.CondArgs...)
 	// log.Println(query)
 	if parsedArgs.Opt.DoNotExec {
-		return nil, newError(doNotExec, query)
+		return nil, newErrorWithArgs(doNotExec, query, queryArgs)
 	}
 
-	err = d.checkAutoCreateTable(prototype, parsedArgs.Opt)
+	err = d.checkAutoCreateTable(ctx, prototype, parsedArgs.Opt)
+	if err != nil {
+		return nil, err
+	}
+	err = d.checkIndexHints(ctx, opt.TableName, parsedArgs.IndexHints)
 	if err != nil {
 		return nil, err
 	}
 
 	// UPDATE
-	res, err := obj.Exec(query)
+	res, err := obj.ExecContext(ctx, query, queryArgs...)
 	if err != nil {
-		err = newError(err.Error(), query)
+		err = newErrorWithArgs(err.Error(), query, queryArgs)
 		return nil, err
 	}
-	return res, err
+	if versionField != nil && record != nil {
+		if n, err := res.RowsAffected(); err == nil && 0 == n {
+			return res, ErrStaleVersion
+		}
+	}
+	return res, nil
 }
 
-func (d *xdb) genUpdateKVs(prototype interface{}, fields map[string]interface{}) ([]string, error) {
+// genUpdateKVs generates "`field` = value" statements. inInsert identifies whether the statements are in
+// 'ON DUPLICATE KEY UPDATE' statement.
+func (d *xdb) genUpdateKVs(
+	prototype interface{}, fields map[string]interface{}, b *binder, inInsert bool,
+) ([]string, error) {
 	fieldMap, err := d.getFieldMap(prototype)
 	if err != nil {
 		return nil, err
 	}
 
+	// sort keys to generate stable statements
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	kv := make([]string, 0, len(fields))
-	for k, v := range fields {
+	for _, k := range keys {
+		v := fields[k]
 		if "" == k {
 			continue
 		}
@@ -103,31 +169,39 @@ func (d *xdb) genUpdateKVs(prototype interface{}, fields map[string]interface{})
 		switch v.(type) {
 		case int, int64, int32, int16, int8:
 			n := reflect.ValueOf(v).Int()
-			kv = append(kv, fmt.Sprintf("`%s` = %d", k, n))
+			kv = append(kv, "`"+k+"`"+" = "+b.bind(strconv.FormatInt(n, 10), n))
 		case uint, uint64, uint32, uint16, uint8:
 			u := reflect.ValueOf(v).Uint()
-			kv = append(kv, fmt.Sprintf("`%s` = %d", k, u))
+			kv = append(kv, "`"+k+"`"+" = "+b.bind(strconv.FormatUint(u, 10), u))
 		case bool:
-			if v.(bool) {
-				kv = append(kv, "`"+k+"`"+" = TRUE")
-			} else {
-				kv = append(kv, "`"+k+"`"+" = FALSE")
-			}
+			kv = append(kv, "`"+k+"`"+" = "+b.bind(convBoolToString(v.(bool)), v))
 		case float32, float64:
 			f := reflect.ValueOf(v).Float()
-			kv = append(kv, fmt.Sprintf("`%s` = %f", k, f))
+			kv = append(kv, "`"+k+"`"+" = "+b.bind(fmt.Sprintf("%f", f), f))
 		case string:
 			s := escapeValueString(v.(string))
-			kv = append(kv, "`"+k+"`"+" = "+addQuoteToString(s, "'"))
+			kv = append(kv, "`"+k+"`"+" = "+b.bind(addQuoteToString(s, "'"), v))
 		case time.Time:
 			t := v.(time.Time)
 			valStr := convTimeToString(t, fieldMap, k)
-			kv = append(kv, "`"+k+"`"+" = "+valStr)
+			kv = append(kv, "`"+k+"`"+" = "+b.bindTime(valStr))
 		case nil:
 			kv = append(kv, "`"+k+"`"+" = NULL")
 		case Raw:
 			valStr := string(v.(Raw))
 			kv = append(kv, "`"+k+"` "+valStr)
+		case *UpdateExpr:
+			valStr, err := v.(*UpdateExpr).pack(k, fieldMap, b, inInsert)
+			if err != nil {
+				return nil, err
+			}
+			kv = append(kv, "`"+k+"` = "+valStr)
+		case *Expression:
+			valStr, err := v.(*Expression).pack(fieldMap, b)
+			if err != nil {
+				return nil, err
+			}
+			kv = append(kv, "`"+k+"` = "+valStr)
 		}
 	}
 	return kv, nil
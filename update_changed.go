@@ -0,0 +1,135 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// noopResult is returned when no statement is executed
+type noopResult struct{}
+
+func (noopResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (noopResult) RowsAffected() (int64, error) {
+	return 0, nil
+}
+
+// UpdateChanged compares two values of the same structure, and updates changed fields only. If no conditions are
+// given, the primary key values of old one will be used as conditions. Primary key, auto-increment and version
+// fields are not compared. If nothing changed, no statement will be executed and a result with zero affected rows
+// is returned.
+//
+// If the structure has a version field, the version in old one is checked and increased in the same way as Save.
+// The version in new one is also increased if a pointer is given.
+func (d *xdb) UpdateChanged(old, new interface{}, conds ...interface{}) (sql.Result, error) {
+	return d.updateChanged(context.Background(), d.db, old, new, conds...)
+}
+
+// UpdateChangedContext is the same as UpdateChanged, with a context.
+func (d *xdb) UpdateChangedContext(ctx context.Context, old, new interface{}, conds ...interface{}) (sql.Result, error) {
+	return d.updateChanged(ctx, d.db, old, new, conds...)
+}
+
+func (d *xdb) updateChanged(
+	ctx context.Context, obj sqlObj, old, new interface{}, conds ...interface{},
+) (sql.Result, error) {
+	oldPrototype, err := getStructPrototype(old)
+	if err != nil {
+		return nil, err
+	}
+	prototype, err := getStructPrototype(new)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.TypeOf(oldPrototype) != reflect.TypeOf(prototype) {
+		return nil, fmt.Errorf("type %T mismatches %T", old, new)
+	}
+
+	fields, err := d.ReadStructFields(prototype)
+	if err != nil {
+		return nil, err
+	}
+	oldValues, err := readStructValues(oldPrototype)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := readStructValues(prototype)
+	if err != nil {
+		return nil, err
+	}
+
+	// conditions
+	hasCond := false
+	var opt Options
+	for _, c := range conds {
+		switch o := c.(type) {
+		case Options:
+			opt = o
+		case *Options:
+			opt = *o
+		default:
+			if isConditionArg(c) {
+				hasCond = true
+			}
+		}
+	}
+	opt = mergeOptions(prototype, opt)
+	keys, err := d.primaryKeyFields(prototype, opt)
+	if err != nil && !hasCond {
+		return nil, err
+	}
+	if !hasCond {
+		keyConds, err := d.primaryKeyConditions(oldPrototype, opt)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, keyConds...)
+	}
+
+	// diff, primary key, auto-increment and version fields are never updated
+	skipped := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		skipped[k] = true
+	}
+	updates := map[string]interface{}{}
+	for _, f := range fields {
+		if skipped[f.Name] || f.AutoIncrement || f.Version {
+			continue
+		}
+		o, n := oldValues[f.Name], newValues[f.Name]
+		if isValueEqual(o, n) {
+			continue
+		}
+		updates[f.Name], err = convStructValueForUpdate(n)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", f.Name, err)
+		}
+	}
+
+	if 0 == len(updates) {
+		return noopResult{}, nil
+	}
+	res, err := d.updateWithVersion(ctx, obj, prototype, oldPrototype, updates, conds...)
+	if err != nil {
+		return res, err
+	}
+	if va := reflect.ValueOf(new); reflect.Ptr == va.Kind() {
+		d.increaseVersion(va.Elem())
+	}
+	return res, nil
+}
+
+// isValueEqual compares two field values. Time values are compared by Equal.
+func isValueEqual(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
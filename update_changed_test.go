@@ -0,0 +1,83 @@
+package mysqlx
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestUpdateChanged(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	old := txTestRecord{String: "changed"}
+	d.MustCreateTable(&old)
+	res, err := d.Insert(&old)
+	if err != nil {
+		t.Errorf("Insert error: %v", err)
+		return
+	}
+	old.ID, _ = res.LastInsertId()
+
+	// nothing changed
+	res, err = d.UpdateChanged(&old, &old)
+	if err != nil {
+		t.Errorf("UpdateChanged error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 0 {
+		t.Errorf("no rows should be affected, but got %d", n)
+		return
+	}
+
+	updated := old
+	updated.String = "changed again"
+	_, err = d.UpdateChanged(&old, &updated, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_tx_test` SET `f_string` = 'changed again' WHERE `f_id` = "+strconv.FormatInt(old.ID, 10) {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	res, err = d.UpdateChanged(old, updated)
+	if err != nil {
+		t.Errorf("UpdateChanged error: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Errorf("one row should be affected, but got %d", n)
+		return
+	}
+
+	// different types
+	_, err = d.UpdateChanged(&old, &User{})
+	if err == nil {
+		t.Errorf("error expected for different types")
+		return
+	}
+}
+
+func TestUpdateChangedWithoutConditions(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	old := txTestRecord{ID: 100, String: "changed"}
+	updated := old
+	updated.ID = 200
+	updated.String = "changed again"
+
+	// non-condition arguments should not replace primary key conditions, and primary key should not be updated
+	_, err = d.UpdateChanged(&old, &updated, Limit(1), Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_tx_test` SET `f_string` = 'changed again' WHERE `f_id` = 100 LIMIT 1" {
+		t.Errorf("unexpected statement")
+		return
+	}
+}
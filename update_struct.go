@@ -0,0 +1,172 @@
+package mysqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Save updates all non-key fields of given structure into database. WHERE conditions are built from primary key,
+// which is the PrimaryKey in Options, or the auto-increment field if PrimaryKey is not declared. If the structure
+// has a version field, it is checked and increased, and ErrStaleVersion is returned if no rows are affected. The
+// version in structure is also increased if a pointer is given.
+func (d *xdb) Save(v interface{}, opts ...Options) (sql.Result, error) {
+	return d.updateStruct(context.Background(), d.db, v, optionsToArgs(opts)...)
+}
+
+// SaveContext is the same as Save, with a context.
+func (d *xdb) SaveContext(ctx context.Context, v interface{}, opts ...Options) (sql.Result, error) {
+	return d.updateStruct(ctx, d.db, v, optionsToArgs(opts)...)
+}
+
+// UpdateStruct is the same as Save, but only fields given by Columns arguments are updated if there are any.
+// Options arguments are also acceptable.
+func (d *xdb) UpdateStruct(v interface{}, args ...interface{}) (sql.Result, error) {
+	return d.updateStruct(context.Background(), d.db, v, args...)
+}
+
+// UpdateStructContext is the same as UpdateStruct, with a context.
+func (d *xdb) UpdateStructContext(ctx context.Context, v interface{}, args ...interface{}) (sql.Result, error) {
+	return d.updateStruct(ctx, d.db, v, args...)
+}
+
+func optionsToArgs(opts []Options) []interface{} {
+	args := make([]interface{}, 0, len(opts))
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	return args
+}
+
+func (d *xdb) updateStruct(ctx context.Context, obj sqlObj, v interface{}, args ...interface{}) (sql.Result, error) {
+	prototype, err := getStructPrototype(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// read arguments
+	var columns []string
+	var opt Options
+	for _, arg := range args {
+		switch a := arg.(type) {
+		default:
+			return nil, fmt.Errorf("unsupported type %T", arg)
+		case ColumnsType:
+			columns = append(columns, a...)
+		case *ColumnsType:
+			columns = append(columns, (*a)...)
+		case Options:
+			opt = a
+		case *Options:
+			opt = *a
+		}
+	}
+
+	fieldMap, err := d.getFieldMap(prototype)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := d.primaryKeyFields(prototype, mergeOptions(prototype, opt))
+	if err != nil {
+		return nil, err
+	}
+	isKey := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		isKey[k] = true
+	}
+
+	values, err := readStructValues(prototype)
+	if err != nil {
+		return nil, err
+	}
+
+	// fields to update. Version field is updated automatically.
+	if 0 == len(columns) {
+		fields, _ := d.ReadStructFields(prototype)
+		for _, f := range fields {
+			if !isKey[f.Name] && !f.AutoIncrement && !f.Version {
+				columns = append(columns, f.Name)
+			}
+		}
+	}
+	updates := make(map[string]interface{}, len(columns))
+	for _, c := range columns {
+		f, exist := fieldMap[c]
+		if !exist {
+			return nil, fmt.Errorf("field '%s' not recognized", c)
+		}
+		if isKey[c] || f.AutoIncrement {
+			return nil, fmt.Errorf("key field '%s' could not be updated", c)
+		}
+		if f.Version {
+			return nil, fmt.Errorf("version field '%s' is updated automatically", c)
+		}
+		updates[c], err = convStructValueForUpdate(values[c])
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", c, err)
+		}
+	}
+	if 0 == len(updates) {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	// conditions by primary key
+	updateArgs, err := d.primaryKeyConditions(prototype, mergeOptions(prototype, opt))
+	if err != nil {
+		return nil, err
+	}
+	updateArgs = append(updateArgs, opt)
+
+	res, err := d.updateWithVersion(ctx, obj, prototype, prototype, updates, updateArgs...)
+	if err != nil {
+		return res, err
+	}
+	if va := reflect.ValueOf(v); reflect.Ptr == va.Kind() {
+		d.increaseVersion(va.Elem())
+	}
+	return res, nil
+}
+
+// primaryKeyFields returns field names of the primary key, which is PrimaryKey in options, or the auto-increment
+// field if not declared.
+func (d *xdb) primaryKeyFields(prototype interface{}, opt Options) ([]string, error) {
+	if len(opt.PrimaryKey) > 0 {
+		fieldMap, err := d.getFieldMap(prototype)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range opt.PrimaryKey {
+			if _, exist := fieldMap[k]; !exist {
+				return nil, fmt.Errorf("primary key field '%s' not recognized", k)
+			}
+		}
+		return opt.PrimaryKey, nil
+	}
+
+	f, err := d.getIncrementField(prototype)
+	if err != nil {
+		return nil, fmt.Errorf("no primary key declared nor auto-increment field found in %T", prototype)
+	}
+	return []string{f.Name}, nil
+}
+
+// convStructValueForUpdate converts a structure field value into types acceptable by genUpdateKVs
+func convStructValueForUpdate(v interface{}) (interface{}, error) {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return v, nil
+	case bool, float32, float64, string, time.Time, nil:
+		return v, nil
+	case driver.Valuer:
+		val, err := v.(driver.Valuer).Value()
+		if err != nil {
+			return nil, err
+		}
+		return convStructValueForUpdate(val)
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+}
@@ -0,0 +1,74 @@
+package mysqlx
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndUpdateStruct(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+
+	r := txTestRecord{String: "save"}
+	d.MustCreateTable(&r)
+	res, err := d.Insert(&r)
+	if err != nil {
+		t.Errorf("Insert error: %v", err)
+		return
+	}
+	r.ID, _ = res.LastInsertId()
+
+	r.String = "saved"
+	_, err = d.Save(&r, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_tx_test` SET `f_string` = 'saved' WHERE `f_id` = "+strconv.FormatInt(r.ID, 10) {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = d.Save(&r)
+	if err != nil {
+		t.Errorf("Save error: %v", err)
+		return
+	}
+	var got txTestRecord
+	err = d.Get(&got, Condition("f_id", "=", r.ID))
+	if err != nil {
+		t.Errorf("Get error: %v", err)
+		return
+	}
+	if got.String != "saved" {
+		t.Errorf("unexpected record: %+v", got)
+		return
+	}
+
+	// chosen fields in transaction
+	tx, err := d.Begin()
+	if err != nil {
+		t.Errorf("Begin error: %v", err)
+		return
+	}
+	r.String = "updated in tx"
+	_, err = tx.UpdateStruct(&r, Columns("f_string"))
+	if err != nil {
+		t.Errorf("UpdateStruct error: %v", err)
+		tx.Rollback()
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		t.Errorf("Commit error: %v", err)
+		return
+	}
+
+	// key fields could not be updated
+	_, err = d.UpdateStruct(&r, Columns("f_id"))
+	if err == nil || !strings.Contains(err.Error(), "f_id") {
+		t.Errorf("error expected for key field, but got %v", err)
+		return
+	}
+}
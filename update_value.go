@@ -0,0 +1,125 @@
+package mysqlx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UpdateExpr identifies a typed value in update fields of Update and InsertOnDuplicateKeyUpdate, such as Incr(1).
+// Identifiers in it are quoted and values are escaped, or bound as placeholders if Param.UsePlaceholder is
+// enabled. Please use the functions below to create an UpdateExpr.
+type UpdateExpr struct {
+	fn   string
+	args []interface{}
+}
+
+// Incr increases the field by n, such as "`count` = `count` + 1"
+func Incr(n interface{}) *UpdateExpr {
+	return &UpdateExpr{fn: "+", args: []interface{}{n}}
+}
+
+// Decr decreases the field by n, such as "`count` = `count` - 1"
+func Decr(n interface{}) *UpdateExpr {
+	return &UpdateExpr{fn: "-", args: []interface{}{n}}
+}
+
+// Mul multiplies the field by n, such as "`price` = `price` * 0.8"
+func Mul(n interface{}) *UpdateExpr {
+	return &UpdateExpr{fn: "*", args: []interface{}{n}}
+}
+
+// Coalesce sets the field with the first non-NULL value in given values, which could be literal values, Col,
+// Values and Now.
+func Coalesce(values ...interface{}) *UpdateExpr {
+	return &UpdateExpr{fn: "COALESCE", args: values}
+}
+
+// Greatest sets the field with the greatest value in given values, which could be literal values, Col, Values and
+// Now. For example, "max_score": Greatest(Col("max_score"), Values("max_score")).
+func Greatest(values ...interface{}) *UpdateExpr {
+	return &UpdateExpr{fn: "GREATEST", args: values}
+}
+
+// Least sets the field with the least value in given values, the same as Greatest.
+func Least(values ...interface{}) *UpdateExpr {
+	return &UpdateExpr{fn: "LEAST", args: values}
+}
+
+// Now sets the field with current time. Fractional seconds precision is the same as the field type.
+func Now() *UpdateExpr {
+	return &UpdateExpr{fn: "NOW"}
+}
+
+// Values references the value of given field in the inserting row. It is only allowed in
+// InsertOnDuplicateKeyUpdate and InsertManyOnDuplicateKeyUpdate, such as "count": Values("count").
+func Values(field string) *UpdateExpr {
+	return &UpdateExpr{fn: "VALUES", args: []interface{}{field}}
+}
+
+// pack returns the value expression for given field. inInsert identifies whether the expression is in
+// 'ON DUPLICATE KEY UPDATE' statement.
+func (u *UpdateExpr) pack(field string, fieldMap map[string]*Field, b *binder, inInsert bool) (string, error) {
+	switch u.fn {
+	default:
+		return "", fmt.Errorf("invalid update expression '%s'", u.fn)
+
+	case "+", "-", "*":
+		switch u.args[0].(type) {
+		case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8, float32, float64:
+			// OK
+		default:
+			return "", fmt.Errorf("invalid operand type %T for field '%s'", u.args[0], field)
+		}
+		n, err := packExpressionArg(u.args[0], fieldMap, b)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s %s", quoteField(field), u.fn, n), nil
+
+	case "COALESCE", "GREATEST", "LEAST":
+		if u.fn != "COALESCE" && len(u.args) < 2 {
+			return "", fmt.Errorf("at least two values are required in %s", u.fn)
+		}
+		if 0 == len(u.args) {
+			return "", fmt.Errorf("no values given in %s", u.fn)
+		}
+		values := make([]string, 0, len(u.args))
+		for _, arg := range u.args {
+			var s string
+			var err error
+			if nested, ok := arg.(*UpdateExpr); ok {
+				switch nested.fn {
+				case "NOW", "VALUES":
+					s, err = nested.pack(field, fieldMap, b, inInsert)
+				default:
+					err = fmt.Errorf("%s is not allowed in %s", nested.fn, u.fn)
+				}
+			} else {
+				s, err = packExpressionArg(arg, fieldMap, b)
+			}
+			if err != nil {
+				return "", err
+			}
+			values = append(values, s)
+		}
+		return fmt.Sprintf("%s(%s)", u.fn, strings.Join(values, ", ")), nil
+
+	case "NOW":
+		if f, exist := fieldMap[field]; exist {
+			if sub := _datetimeRegex.FindStringSubmatch(strings.ToLower(f.Type)); len(sub) == 2 && sub[1] != "0" {
+				return "NOW(" + sub[1] + ")", nil
+			}
+		}
+		return "NOW()", nil
+
+	case "VALUES":
+		if !inInsert {
+			return "", fmt.Errorf("VALUES() is only allowed in ON DUPLICATE KEY UPDATE statement")
+		}
+		col, err := ColumnRef(u.args[0].(string)).pack(fieldMap)
+		if err != nil {
+			return "", err
+		}
+		return "VALUES(" + col + ")", nil
+	}
+}
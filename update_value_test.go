@@ -0,0 +1,69 @@
+package mysqlx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpdateValues(t *testing.T) {
+	db, err := getDB()
+	if err != nil {
+		t.Errorf("getDB error: %v", err)
+		return
+	}
+
+	err = db.CreateTable(User{})
+	if err != nil {
+		t.Errorf("CreateTable error: %v", err)
+		return
+	}
+
+	fields := map[string]interface{}{
+		"status_masks": Incr(1),
+	}
+	_, err = db.Update(User{}, fields, Condition("full_name", "=", "Mickey Mouse"), Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.HasPrefix(query, "UPDATE `t_user` SET `status_masks` = `status_masks` + 1 WHERE") {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	fields = map[string]interface{}{
+		"status_masks": Greatest(Col("status_masks"), 1),
+		"nation":       Coalesce(Col("nation"), "USA"),
+	}
+	_, err = db.Update(User{}, fields, Condition("full_name", "=", "Mickey Mouse"))
+	if err != nil {
+		t.Errorf("Update error: %v", err)
+		return
+	}
+
+	// VALUES() is only allowed in ON DUPLICATE KEY UPDATE
+	_, err = db.Update(User{}, map[string]interface{}{"status_masks": Values("status_masks")})
+	if err == nil {
+		t.Errorf("error expected for VALUES() in UPDATE")
+		return
+	}
+
+	u := User{FullName: "Donald Duck", StatusMasks: 2}
+	updates := map[string]interface{}{
+		"status_masks": Incr(Values("status_masks")),
+	}
+	_, err = db.InsertOnDuplicateKeyUpdate(&u, updates)
+	if err == nil {
+		t.Errorf("error expected for non-numeric operand")
+		return
+	}
+
+	updates = map[string]interface{}{
+		"status_masks": Greatest(Col("status_masks"), Values("status_masks")),
+	}
+	_, err = db.InsertOnDuplicateKeyUpdate(&u, updates, Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if !strings.HasSuffix(query, "ON DUPLICATE KEY UPDATE `status_masks` = GREATEST(`status_masks`, VALUES(`status_masks`))") {
+		t.Errorf("unexpected statement")
+		return
+	}
+}
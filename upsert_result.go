@@ -0,0 +1,143 @@
+package mysqlx
+
+import (
+	"database/sql"
+	"strconv"
+)
+
+// UpsertOutcome identifies what an 'INSERT ... ON DUPLICATE KEY UPDATE' statement did
+type UpsertOutcome int
+
+const (
+	// UpsertUnknown means the outcome could not be determined by the affected-rows value, such as a mix of
+	// inserted and updated records in InsertManyOnDuplicateKeyUpdate.
+	UpsertUnknown UpsertOutcome = iota
+	// UpsertInserted means new records are inserted
+	UpsertInserted
+	// UpsertUpdated means existing records are updated
+	UpsertUpdated
+	// UpsertUnchanged means existing records are found but not changed
+	UpsertUnchanged
+	// UpsertInsertedOrUnchanged means records are inserted, or found but not changed. MySQL could not tell them
+	// apart if CLIENT_FOUND_ROWS is enabled.
+	UpsertInsertedOrUnchanged
+)
+
+func (o UpsertOutcome) String() string {
+	switch o {
+	case UpsertUnknown:
+		return "unknown"
+	case UpsertInserted:
+		return "inserted"
+	case UpsertUpdated:
+		return "updated"
+	case UpsertUnchanged:
+		return "unchanged"
+	case UpsertInsertedOrUnchanged:
+		return "inserted or unchanged"
+	default:
+		return "UpsertOutcome(" + strconv.Itoa(int(o)) + ")"
+	}
+}
+
+// UpsertBatch counts one 'INSERT ... ON DUPLICATE KEY UPDATE' statement. For each record, MySQL counts 1
+// affected row if it is inserted and 2 if it is updated. An unchanged record is counted as 0, or 1 if
+// CLIENT_FOUND_ROWS is enabled.
+type UpsertBatch struct {
+	// Records is the number of records in the statement
+	Records int64
+	// RowsAffected is the affected-rows value returned by MySQL
+	RowsAffected int64
+	// Updated is the number of updated records. It is -1 if it could not be determined, which only happens when
+	// CLIENT_FOUND_ROWS is not enabled.
+	Updated int64
+}
+
+// UpsertResult is returned by InsertOnDuplicateKeyUpdateResult and InsertManyOnDuplicateKeyUpdateResult
+type UpsertResult interface {
+	sql.Result
+
+	// Outcome returns what the statements did. For InsertManyOnDuplicateKeyUpdate, it is determined only if all
+	// records have the same outcome, otherwise UpsertUnknown is returned.
+	Outcome() UpsertOutcome
+
+	// Batches returns counters of each executed statement. InsertManyOnDuplicateKeyUpdate may split records into
+	// multiple statements.
+	Batches() []UpsertBatch
+}
+
+type upsertResult struct {
+	sql.Result
+	clientFoundRows bool
+	batches         []UpsertBatch
+}
+
+// clientFoundRows tells whether CLIENT_FOUND_ROWS flag is enabled by "clientFoundRows" in Param.Params
+func (d *xdb) clientFoundRows() bool {
+	b, _ := strconv.ParseBool(d.param.Params["clientFoundRows"])
+	return b
+}
+
+func (d *xdb) newUpsertResult(res sql.Result) *upsertResult {
+	return &upsertResult{
+		Result:          res,
+		clientFoundRows: d.clientFoundRows(),
+	}
+}
+
+// add adds a statement result with given number of records
+func (r *upsertResult) add(records int, res sql.Result) {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		affected = -1
+	}
+	batch := UpsertBatch{
+		Records:      int64(records),
+		RowsAffected: affected,
+		Updated:      -1,
+	}
+	switch {
+	case affected < 0:
+		// unknown
+	case r.clientFoundRows:
+		batch.Updated = affected - batch.Records
+	case affected == 0:
+		batch.Updated = 0
+	case affected == 2*batch.Records:
+		batch.Updated = batch.Records
+	}
+	r.batches = append(r.batches, batch)
+}
+
+func (r *upsertResult) Batches() []UpsertBatch {
+	return r.batches
+}
+
+func (r *upsertResult) Outcome() UpsertOutcome {
+	var records, affected int64
+	for _, b := range r.batches {
+		if b.RowsAffected < 0 {
+			return UpsertUnknown
+		}
+		records += b.Records
+		affected += b.RowsAffected
+	}
+	if 0 == records {
+		return UpsertUnknown
+	}
+
+	switch {
+	case affected == 2*records:
+		return UpsertUpdated
+	case r.clientFoundRows && affected == records:
+		return UpsertInsertedOrUnchanged
+	case r.clientFoundRows:
+		return UpsertUnknown
+	case affected == 0:
+		return UpsertUnchanged
+	case affected == records && 1 == records:
+		return UpsertInserted
+	default:
+		return UpsertUnknown
+	}
+}
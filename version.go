@@ -0,0 +1,79 @@
+package mysqlx
+
+import "reflect"
+
+// This file handles optimistic locking. A version field is tagged by `mysqlx:"version:true"`, which should be an
+// integer. Save, UpdateStruct and UpdateChanged add "`version` = ?" condition with the value in given structure,
+// and increase the field by one. If no rows are affected, ErrStaleVersion is returned. Update does the same if the
+// version in its prototype is not zero, otherwise it only increases the field, as zero prototypes are used by
+// UpdateT and Query.Update.
+
+// versionField returns the version field of given structure, or nil if there is none
+func (d *xdb) versionField(prototype interface{}) *Field {
+	fields, err := d.ReadStructFields(prototype)
+	if err != nil {
+		return nil
+	}
+	for _, f := range fields {
+		if f.Version {
+			return f
+		}
+	}
+	return nil
+}
+
+// hasVersion tells whether given structure has a version field with non-zero value
+func (d *xdb) hasVersion(prototype interface{}) bool {
+	f := d.versionField(prototype)
+	if f == nil {
+		return false
+	}
+	values, err := readStructValues(prototype)
+	if err != nil {
+		return false
+	}
+	v := reflect.ValueOf(values[f.Name])
+	return v.IsValid() && !v.IsZero()
+}
+
+// versionUpdateArgs returns copies of update fields and arguments with version increment. Version condition with
+// the value in record is also added if record is not nil.
+func versionUpdateArgs(
+	record interface{}, f *Field, fields map[string]interface{}, args []interface{},
+) (map[string]interface{}, []interface{}) {
+	newFields := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		newFields[k] = v
+	}
+	newFields[f.Name] = Incr(1)
+	if nil == record {
+		return newFields, args
+	}
+
+	values, _ := readStructValues(record)
+	newArgs := make([]interface{}, 0, len(args)+1)
+	newArgs = append(newArgs, args...)
+	newArgs = append(newArgs, Condition(f.Name, "=", values[f.Name]))
+	return newFields, newArgs
+}
+
+// increaseVersion increases the version field of an addressable structure value after updating
+func (d *xdb) increaseVersion(v reflect.Value) {
+	if !v.IsValid() || !v.CanSet() {
+		return
+	}
+	f := d.versionField(v.Interface())
+	if f == nil {
+		return
+	}
+	fv, ok := findStructField(v, f.Name)
+	if !ok || !fv.CanSet() {
+		return
+	}
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(fv.Int() + 1)
+	default:
+		fv.SetUint(fv.Uint() + 1)
+	}
+}
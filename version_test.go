@@ -0,0 +1,137 @@
+package mysqlx
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+type versionRecord struct {
+	ID      int64  `db:"f_id"          mysqlx:"increment:true"`
+	String  string `db:"f_string"      mysqlx:"type:varchar(128)"`
+	Version int32  `db:"f_version"     mysqlx:"version:true"`
+}
+
+func (versionRecord) Options() Options {
+	return Options{
+		TableName: "t_mysqlx_version_test",
+	}
+}
+
+func TestVersion(t *testing.T) {
+	d, err := getDB()
+	if err != nil {
+		t.Errorf("open failed: %v", err)
+		return
+	}
+	d.MustCreateTable(versionRecord{})
+
+	r := versionRecord{String: "version"}
+	_, err = d.Insert(&r)
+	if err != nil {
+		t.Errorf("Insert error: %v", err)
+		return
+	}
+
+	// statements
+	_, err = d.Save(&r, Options{DoNotExec: true})
+	query := GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	id := strconv.FormatInt(r.ID, 10)
+	if query != "UPDATE `t_mysqlx_version_test` SET `f_string` = 'version', `f_version` = `f_version` + 1 "+
+		"WHERE `f_id` = "+id+" AND `f_version` = 0" {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	_, err = d.Update(r, map[string]interface{}{"f_version": 10}, Condition("f_id", "=", r.ID), Options{DoNotExec: true})
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_version_test` SET `f_version` = 10 WHERE `f_id` = "+id {
+		t.Errorf("unexpected statement")
+		return
+	}
+
+	// compare and swap
+	stale := r
+	r.String = "updated"
+	_, err = d.Save(&r)
+	if err != nil {
+		t.Errorf("Save error: %v", err)
+		return
+	}
+	if r.Version != 1 {
+		t.Errorf("version should be increased, but got %d", r.Version)
+		return
+	}
+
+	stale.String = "stale"
+	_, err = d.Save(&stale)
+	if !errors.Is(err, ErrStaleVersion) {
+		t.Errorf("ErrStaleVersion expected, but got %v", err)
+		return
+	}
+	if stale.Version != 0 {
+		t.Errorf("version should not be increased, but got %d", stale.Version)
+		return
+	}
+
+	// version is increased but not checked by zero prototypes
+	_, err = UpdateT[versionRecord](
+		d, map[string]interface{}{"f_string": "generic"}, Condition("f_id", "=", r.ID), Options{DoNotExec: true},
+	)
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_version_test` SET `f_string` = 'generic', `f_version` = `f_version` + 1 "+
+		"WHERE `f_id` = "+id {
+		t.Errorf("unexpected statement")
+		return
+	}
+	_, err = UpdateT[versionRecord](d, map[string]interface{}{"f_string": "generic"}, Condition("f_id", "=", r.ID))
+	if err != nil {
+		t.Errorf("UpdateT error: %v", err)
+		return
+	}
+	_, err = d.From(&versionRecord{}).Where("f_id", "=", r.ID).Update(map[string]interface{}{"f_string": "builder"})
+	if err != nil {
+		t.Errorf("Query.Update error: %v", err)
+		return
+	}
+	var got versionRecord
+	if err = d.Get(&got, Condition("f_id", "=", r.ID)); err != nil {
+		t.Errorf("Get error: %v", err)
+		return
+	}
+	if got.String != "builder" || got.Version != 3 {
+		t.Errorf("unexpected record: %+v", got)
+		return
+	}
+
+	// non-zero version in prototype is checked by Update
+	_, err = d.Update(
+		got, map[string]interface{}{"f_string": "update"}, Condition("f_id", "=", r.ID), Options{DoNotExec: true},
+	)
+	query = GetQueryFromError(err)
+	t.Logf("statement: %s", query)
+	if query != "UPDATE `t_mysqlx_version_test` SET `f_string` = 'update', `f_version` = `f_version` + 1 "+
+		"WHERE `f_id` = "+id+" AND `f_version` = 3" {
+		t.Errorf("unexpected statement")
+		return
+	}
+	_, err = d.Update(got, map[string]interface{}{"f_string": "update"}, Condition("f_id", "=", r.ID))
+	if err != nil {
+		t.Errorf("Update error: %v", err)
+		return
+	}
+	_, err = d.Update(r, map[string]interface{}{"f_string": "stale"}, Condition("f_id", "=", r.ID))
+	if !errors.Is(err, ErrStaleVersion) {
+		t.Errorf("ErrStaleVersion expected, but got %v", err)
+		return
+	}
+
+	_, err = d.UpdateStruct(&r, Columns("f_version"))
+	if err == nil {
+		t.Errorf("error expected for updating version field")
+		return
+	}
+}